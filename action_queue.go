@@ -0,0 +1,243 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+	"github.com/google/go-github/v55/github"
+)
+
+const UNDO_DELAY time.Duration = time.Second * 5
+
+type ActionKind string
+
+const (
+	ActionRead        ActionKind = "read"
+	ActionDone        ActionKind = "done"
+	ActionUnsubscribe ActionKind = "unsubscribe"
+)
+
+// PendingAction is a read, done or unsubscribe waiting out its undo delay.
+// Its effect is already shown locally; when the timer fires it moves to the
+// outbox, unless it is undone first.
+type PendingAction struct {
+	ID           int
+	Kind         ActionKind
+	Notification *github.Notification
+	timer        *time.Timer
+}
+
+var actionQueue = struct {
+	sync.Mutex
+	nextID  int
+	pending map[int]*PendingAction
+}{
+	pending: make(map[int]*PendingAction),
+}
+
+var snackbar *fyne.Container
+var snackbarLabel *widget.Label
+
+func actionMessage(kind ActionKind) string {
+	switch kind {
+	case ActionRead:
+		return tr("Marked as read")
+	case ActionDone:
+		return tr("Marked as done")
+	case ActionUnsubscribe:
+		return tr("Unsubscribed")
+	}
+
+	return string(kind)
+}
+
+func actionFunc(kind ActionKind) func(context.Context, *github.Notification) (bool, error) {
+	switch kind {
+	case ActionRead:
+		return markAsReadNotification
+	case ActionDone:
+		return markAsDoneNotification
+	case ActionUnsubscribe:
+		return unsubscribeNotification
+	}
+
+	return nil
+}
+
+// queueAction shows the action's effect right away and runs it after
+// UNDO_DELAY unless undone in the meantime.
+func queueAction(kind ActionKind, notification *github.Notification) {
+	actionQueue.Lock()
+	actionQueue.nextID++
+	action := &PendingAction{
+		ID:           actionQueue.nextID,
+		Kind:         kind,
+		Notification: notification,
+	}
+	actionQueue.pending[action.ID] = action
+	action.timer = time.AfterFunc(UNDO_DELAY, func() {
+		runPendingAction(action.ID)
+	})
+	actionQueue.Unlock()
+
+	applyActionLocally(kind, notification)
+	showSnackbar(action)
+}
+
+func runPendingAction(id int) {
+	actionQueue.Lock()
+	action, ok := actionQueue.pending[id]
+	delete(actionQueue.pending, id)
+	actionQueue.Unlock()
+
+	if !ok {
+		return
+	}
+
+	enqueueOutbox(action.Kind, action.Notification)
+
+	runOnUI(func() {
+		if id == currentSnackbarActionID() {
+			hideSnackbar()
+		}
+	})
+}
+
+func undoAction(id int) {
+	actionQueue.Lock()
+	action, ok := actionQueue.pending[id]
+
+	if ok && action.timer.Stop() {
+		delete(actionQueue.pending, id)
+	} else {
+		ok = false
+	}
+	actionQueue.Unlock()
+
+	hideSnackbar()
+
+	if !ok {
+		slog.Debug("Too late to undo", "action", id)
+		return
+	}
+
+	revertActionLocally(action.Kind, action.Notification)
+}
+
+// flushPendingActions sends every pending action now, e.g. before quitting,
+// taking at most OUTBOX_FLUSH_TIMEOUT. Whatever cannot be sent stays in the
+// outbox for the next start.
+func flushPendingActions() {
+	actionQueue.Lock()
+	var ids []int
+	for id, action := range actionQueue.pending {
+		if action.timer.Stop() {
+			ids = append(ids, id)
+		}
+	}
+	actionQueue.Unlock()
+
+	for _, id := range ids {
+		runPendingAction(id)
+	}
+
+	ctx, cancel := context.WithTimeout(globalCtx, OUTBOX_FLUSH_TIMEOUT)
+	defer cancel()
+
+	sendOutbox(ctx)
+}
+
+func applyActionLocally(kind ActionKind, notification *github.Notification) {
+	switch kind {
+	case ActionRead:
+		notificationStore.MarkRead(notification.GetID())
+	case ActionDone:
+		notificationStore.Remove(notification.GetID())
+	}
+}
+
+func revertActionLocally(kind ActionKind, notification *github.Notification) {
+	if kind == ActionRead || kind == ActionDone {
+		notificationStore.Upsert(notification)
+	}
+}
+
+// addActionKind records the action with the most visible effect: done hides
+// the notification, so it wins over read.
+func addActionKind(kinds map[string]ActionKind, id string, kind ActionKind) {
+	if kind == ActionUnsubscribe || kinds[id] == ActionDone {
+		return
+	}
+
+	kinds[id] = kind
+}
+
+// applyPendingActions overlays pending and unsent actions on freshly fetched
+// notifications, so a poll does not bring them back before GitHub has them.
+func applyPendingActions(notifications []*github.Notification) []*github.Notification {
+	kinds := make(map[string]ActionKind)
+
+	actionQueue.Lock()
+	for _, action := range actionQueue.pending {
+		addActionKind(kinds, action.Notification.GetID(), action.Kind)
+	}
+	actionQueue.Unlock()
+
+	outboxKinds(kinds)
+
+	if len(kinds) == 0 {
+		return notifications
+	}
+
+	var result []*github.Notification
+
+	for _, notification := range notifications {
+		switch kinds[notification.GetID()] {
+		case ActionDone:
+			continue
+		case ActionRead:
+			read := *notification
+			unread := false
+			read.Unread = &unread
+			notification = &read
+		}
+
+		result = append(result, notification)
+	}
+
+	return result
+}
+
+func addSnackbarUI() fyne.CanvasObject {
+	snackbarLabel = widget.NewLabel("")
+
+	snackbar = container.NewHBox(
+		snackbarLabel,
+		layout.NewSpacer(),
+		widget.NewButton(tr("Undo"), func() {
+			undoAction(currentSnackbarActionID())
+		}),
+	)
+	snackbar.Hide()
+
+	return snackbar
+}
+
+func showSnackbar(action *PendingAction) {
+	setSnackbarActionID(action.ID)
+
+	snackbarLabel.SetText(fmt.Sprintf("%s — %s", actionMessage(action.Kind), excerpt(action.Notification.GetSubject().GetTitle(), 30)))
+	snackbar.Show()
+}
+
+func hideSnackbar() {
+	setSnackbarActionID(0)
+	snackbar.Hide()
+}
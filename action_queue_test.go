@@ -0,0 +1,125 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/google/go-github/v55/github"
+)
+
+func pendingActionCount() int {
+	actionQueue.Lock()
+	defer actionQueue.Unlock()
+
+	return len(actionQueue.pending)
+}
+
+func outboxLen() int {
+	outbox.Lock()
+	defer outbox.Unlock()
+
+	return len(outbox.entries)
+}
+
+func TestUndoActionRevertsLocally(t *testing.T) {
+	tests := []struct {
+		kind ActionKind
+		// listed and unread are how notification 1 shows while pending
+		listed bool
+		unread bool
+	}{
+		{ActionRead, true, false},
+		{ActionDone, false, false},
+		{ActionUnsubscribe, true, true},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.kind), func(t *testing.T) {
+			fake := useFakeGitHub(t, 0)
+			notifications := []*github.Notification{fake.notification(1), fake.notification(2)}
+
+			runOnUI(func() {
+				addNotifications(notifications, nil)
+				queueAction(test.kind, notifications[0])
+			})
+			waitUI(t)
+
+			pending := notificationStore.Find("1")
+
+			if listed := pending != nil; listed != test.listed {
+				t.Fatalf("listed %v while pending, want %v", listed, test.listed)
+			}
+
+			if pending != nil && pending.GetUnread() != test.unread {
+				t.Errorf("unread %v while pending, want %v", pending.GetUnread(), test.unread)
+			}
+
+			// a poll in the meantime does not bring it back
+			if refetched := applyPendingActions(notifications); test.kind == ActionDone && len(refetched) != 1 {
+				t.Errorf("%d notifications after a poll, want the pending done one hidden", len(refetched))
+			}
+
+			runOnUI(func() {
+				if !snackbar.Visible() {
+					t.Error("snackbar not shown")
+				}
+
+				undoAction(currentSnackbarActionID())
+			})
+			waitUI(t)
+
+			if snackbar.Visible() {
+				t.Error("snackbar still shown after undo")
+			}
+
+			if count := pendingActionCount(); count != 0 {
+				t.Errorf("%d actions pending after undo", count)
+			}
+
+			if restored := notificationStore.Find("1"); restored == nil || !restored.GetUnread() {
+				t.Errorf("notification not restored after undo: %v", restored)
+			}
+
+			if count := outboxLen(); count != 0 {
+				t.Errorf("%d actions sent after undo", count)
+			}
+		})
+	}
+}
+
+func TestFlushPendingActionsSendsNow(t *testing.T) {
+	fake := useFakeGitHub(t, 0)
+	notifications := []*github.Notification{fake.notification(1), fake.notification(2), fake.notification(3)}
+
+	runOnUI(func() {
+		addNotifications(notifications, nil)
+		queueAction(ActionRead, notifications[0])
+		queueAction(ActionDone, notifications[1])
+		queueAction(ActionUnsubscribe, notifications[2])
+	})
+	waitUI(t)
+
+	flushPendingActions()
+	waitUI(t)
+
+	if count := pendingActionCount(); count != 0 {
+		t.Errorf("%d actions still pending after the flush", count)
+	}
+
+	if count := outboxLen(); count != 0 {
+		t.Errorf("%d actions left in the outbox after the flush", count)
+	}
+
+	for _, request := range []string{
+		"PATCH /notifications/threads/1",
+		"DELETE /notifications/threads/2",
+		"DELETE /notifications/threads/3/subscription",
+	} {
+		if count := fake.requestCount(request); count != 1 {
+			t.Errorf("%s sent %d times, want once", request, count)
+		}
+	}
+
+	if snackbar.Visible() {
+		t.Error("snackbar still shown after the flush")
+	}
+}
@@ -0,0 +1,171 @@
+//go:build linux
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+const AUTOSTART_FILE string = APP_ID + ".desktop"
+const SYSTEMD_UNIT string = APP_ID + ".service"
+
+var systemctlCommand = "systemctl"
+
+func userConfigDir() string {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return dir
+	}
+
+	home, _ := os.UserHomeDir()
+
+	return filepath.Join(home, ".config")
+}
+
+func autostartPath() string {
+	return filepath.Join(userConfigDir(), "autostart", AUTOSTART_FILE)
+}
+
+func systemdUnitPath() string {
+	return filepath.Join(userConfigDir(), "systemd", "user", SYSTEMD_UNIT)
+}
+
+// setAutostart installs the XDG autostart entry or the systemd user unit
+// that starts the app minimised on login, and removes the other one. It is
+// called on every launch, so the files follow the binary when it moves, but
+// they are only rewritten, and systemd only reloaded, when they changed.
+func setAutostart(startup StartupConfig) error {
+	executable, err := os.Executable()
+
+	if err != nil {
+		return err
+	}
+
+	if resolved, err := filepath.EvalSymlinks(executable); err == nil {
+		executable = resolved
+	}
+
+	var errs []error
+
+	if startup.Autostart && !startup.Systemd {
+		_, err := writeAutostartFile(autostartPath(), desktopEntry(executable))
+		errs = append(errs, err)
+	} else {
+		errs = append(errs, removeAutostartFile(autostartPath()))
+	}
+
+	if startup.Autostart && startup.Systemd {
+		changed, err := writeAutostartFile(systemdUnitPath(), systemdUnit(executable))
+		errs = append(errs, err)
+
+		if changed {
+			errs = append(errs, systemctl("daemon-reload"))
+			errs = append(errs, systemctl("enable", SYSTEMD_UNIT))
+		}
+	} else if _, err := os.Stat(systemdUnitPath()); err == nil {
+		errs = append(errs, systemctl("disable", SYSTEMD_UNIT))
+		errs = append(errs, removeAutostartFile(systemdUnitPath()))
+		errs = append(errs, systemctl("daemon-reload"))
+	}
+
+	return errors.Join(errs...)
+}
+
+func desktopEntry(executable string) string {
+	return fmt.Sprintf(`[Desktop Entry]
+Type=Application
+Name=GitHub Notify
+Comment=GitHub notifications on the desktop
+Exec=%s --minimized
+Terminal=false
+X-GNOME-Autostart-enabled=true
+`, desktopExecQuote(executable))
+}
+
+// systemdUnit runs the app in the graphical session, restarting it when
+// it crashes.
+func systemdUnit(executable string) string {
+	return fmt.Sprintf(`[Unit]
+Description=GitHub Notify
+PartOf=graphical-session.target
+After=graphical-session.target
+
+[Service]
+ExecStart=%s --minimized
+Restart=on-failure
+RestartSec=10
+
+[Install]
+WantedBy=graphical-session.target
+`, systemdExecQuote(executable))
+}
+
+// desktopExecQuote quotes a path for a desktop entry's Exec= line. Inside
+// double quotes the backslash escapes ", `, $ and itself, but the value is
+// unescaped as a string first, which halves every backslash, so they are
+// written twice: a backslash becomes \\\\ and a dollar sign \\$. A percent
+// sign starts a field code and is doubled.
+func desktopExecQuote(path string) string {
+	path = strings.ReplaceAll(path, "%", "%%")
+
+	if !strings.ContainsAny(path, " \t\n\"'\\><~|&;$*?#()`") {
+		return path
+	}
+
+	replacer := strings.NewReplacer(`\`, `\\\\`, `"`, `\\"`, "`", "\\\\`", "$", `\\$`, "\t", `\t`, "\n", `\n`)
+
+	return `"` + replacer.Replace(path) + `"`
+}
+
+// systemdExecQuote quotes a path for a unit's ExecStart= line, which takes C
+// escapes inside double quotes, expands $ as an environment variable and %
+// as a specifier, so those two are doubled.
+func systemdExecQuote(path string) string {
+	path = strings.NewReplacer("%", "%%", "$", "$$").Replace(path)
+
+	if !strings.ContainsAny(path, " \t\n\"'\\") {
+		return path
+	}
+
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\t", `\t`, "\n", `\n`)
+
+	return `"` + replacer.Replace(path) + `"`
+}
+
+// writeAutostartFile writes content to path unless it is there already, and
+// reports whether it wrote it.
+func writeAutostartFile(path string, content string) (bool, error) {
+	if current, err := os.ReadFile(path); err == nil && string(current) == content {
+		return false, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return false, err
+	}
+
+	return true, os.WriteFile(path, []byte(content), 0o644)
+}
+
+func removeAutostartFile(path string) error {
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+func systemctl(args ...string) error {
+	output, err := exec.Command(systemctlCommand, append([]string{"--user"}, args...)...).CombinedOutput()
+
+	if err != nil {
+		slog.Warn("systemctl failed", "args", strings.Join(args, " "), "output", strings.TrimSpace(string(output)))
+		return fmt.Errorf("systemctl --user %s: %w", strings.Join(args, " "), err)
+	}
+
+	return nil
+}
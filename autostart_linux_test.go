@@ -0,0 +1,88 @@
+//go:build linux
+
+package main
+
+import (
+	"os"
+	"testing"
+)
+
+func TestDesktopExecQuote(t *testing.T) {
+	tests := map[string]string{
+		"/usr/bin/notify":            "/usr/bin/notify",
+		"/opt/my apps/notify":        `"/opt/my apps/notify"`,
+		`/opt/back\slash/notify`:     `"/opt/back\\\\slash/notify"`,
+		"/opt/$HOME/notify":          `"/opt/\\$HOME/notify"`,
+		`/opt/"quoted"/notify`:       `"/opt/\\"quoted\\"/notify"`,
+		"/opt/`tick`/notify":         "\"/opt/\\\\`tick\\\\`/notify\"",
+		"/opt/100%/notify":           "/opt/100%%/notify",
+		"/opt/100% done/notify":      `"/opt/100%% done/notify"`,
+		"/home/me/apps (old)/notify": `"/home/me/apps (old)/notify"`,
+	}
+
+	for path, want := range tests {
+		if got := desktopExecQuote(path); got != want {
+			t.Errorf("desktopExecQuote(%q) = %s, want %s", path, got, want)
+		}
+	}
+}
+
+func TestSystemdExecQuote(t *testing.T) {
+	tests := map[string]string{
+		"/usr/bin/notify":        "/usr/bin/notify",
+		"/opt/my apps/notify":    `"/opt/my apps/notify"`,
+		`/opt/back\slash/notify`: `"/opt/back\\slash/notify"`,
+		"/opt/$HOME/notify":      "/opt/$$HOME/notify",
+		`/opt/"quoted"/notify`:   `"/opt/\"quoted\"/notify"`,
+		"/opt/100%/notify":       "/opt/100%%/notify",
+		"/opt/100% $x/notify":    `"/opt/100%% $$x/notify"`,
+	}
+
+	for path, want := range tests {
+		if got := systemdExecQuote(path); got != want {
+			t.Errorf("systemdExecQuote(%q) = %s, want %s", path, got, want)
+		}
+	}
+}
+
+func TestSetAutostartOnlyReloadsChangedUnit(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	// fails, so an error tells that systemctl ran
+	systemctlCommand = "false"
+	defer func() { systemctlCommand = "systemctl" }()
+
+	startup := StartupConfig{Autostart: true, Systemd: true}
+
+	if err := setAutostart(startup); err == nil {
+		t.Error("systemctl not run for a new unit")
+	}
+
+	info, err := os.Stat(systemdUnitPath())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := setAutostart(startup); err != nil {
+		t.Errorf("systemctl run for an unchanged unit: %s", err)
+	}
+
+	if again, _ := os.Stat(systemdUnitPath()); !again.ModTime().Equal(info.ModTime()) {
+		t.Error("unchanged unit rewritten")
+	}
+
+	startup.Systemd = false
+
+	if err := setAutostart(startup); err == nil {
+		t.Error("systemctl not run to remove the unit")
+	}
+
+	if _, err := os.Stat(autostartPath()); err != nil {
+		t.Errorf("autostart entry not written: %s", err)
+	}
+
+	if _, err := os.Stat(systemdUnitPath()); !os.IsNotExist(err) {
+		t.Error("unit not removed")
+	}
+}
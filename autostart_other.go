@@ -0,0 +1,13 @@
+//go:build !linux
+
+package main
+
+import "errors"
+
+func setAutostart(startup StartupConfig) error {
+	if startup.Autostart {
+		return errors.New(tr("starting on login is only supported on Linux; add the app to your login items instead"))
+	}
+
+	return nil
+}
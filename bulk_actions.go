@@ -0,0 +1,253 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+	"github.com/google/go-github/v55/github"
+)
+
+const BULK_PARALLELISM int = 4
+
+// bulkSelection is the set of rows ticked for bulk actions. It is separate
+// from the list's own single selection, which drives the detail pane.
+var bulkSelection = struct {
+	sync.Mutex
+	ids    map[string]bool
+	anchor string
+}{
+	ids: make(map[string]bool),
+}
+
+var bulkBar *fyne.Container
+var bulkLabel *widget.Label
+var bulkButtons *fyne.Container
+var bulkProgress *widget.ProgressBar
+
+func isBulkSelected(id string) bool {
+	bulkSelection.Lock()
+	defer bulkSelection.Unlock()
+
+	return bulkSelection.ids[id]
+}
+
+func setBulkSelected(id string, selected bool) {
+	bulkSelection.Lock()
+
+	if selected {
+		bulkSelection.ids[id] = true
+	} else {
+		delete(bulkSelection.ids, id)
+	}
+
+	bulkSelection.anchor = id
+	bulkSelection.Unlock()
+
+	refreshBulkActionsUI()
+}
+
+// selectBulkRange ticks every row between the last ticked row and id.
+func selectBulkRange(id string) {
+	bulkSelection.Lock()
+
+	from := notificationStore.IndexOf(bulkSelection.anchor)
+	to := notificationStore.IndexOf(id)
+
+	if from == -1 {
+		from = to
+	}
+
+	if from > to {
+		from, to = to, from
+	}
+
+	for i := from; i <= to && i != -1; i++ {
+		if notification := notificationStore.At(i); notification != nil {
+			bulkSelection.ids[notification.GetID()] = true
+		}
+	}
+
+	bulkSelection.anchor = id
+	bulkSelection.Unlock()
+
+	notificationListComponent.Refresh()
+	refreshBulkActionsUI()
+}
+
+func clearBulkSelection() {
+	bulkSelection.Lock()
+	bulkSelection.ids = make(map[string]bool)
+	bulkSelection.anchor = ""
+	bulkSelection.Unlock()
+
+	notificationListComponent.Refresh()
+	refreshBulkActionsUI()
+}
+
+// pruneBulkSelection unticks notifications that are no longer fetched. Ones
+// only hidden by the filter stay ticked for when they are shown again.
+func pruneBulkSelection() {
+	fetched := make(map[string]bool)
+
+	for _, notification := range notificationStore.Fetched() {
+		fetched[notification.GetID()] = true
+	}
+
+	bulkSelection.Lock()
+
+	for id := range bulkSelection.ids {
+		if !fetched[id] {
+			delete(bulkSelection.ids, id)
+		}
+	}
+
+	bulkSelection.Unlock()
+
+	refreshBulkActionsUI()
+}
+
+func bulkSelectedNotifications() []*github.Notification {
+	bulkSelection.Lock()
+	defer bulkSelection.Unlock()
+
+	var notifications []*github.Notification
+
+	for _, notification := range notificationStore.All() {
+		if bulkSelection.ids[notification.GetID()] {
+			notifications = append(notifications, notification)
+		}
+	}
+
+	return notifications
+}
+
+func addBulkActionsUI() fyne.CanvasObject {
+	bulkLabel = widget.NewLabel("")
+
+	bulkButtons = container.NewHBox(
+		widget.NewButton(tr("Read"), func() {
+			runBulkAction(ActionRead)
+		}),
+		widget.NewButton(tr("Done"), func() {
+			runBulkAction(ActionDone)
+		}),
+		widget.NewButton(tr("Unsubscribe"), func() {
+			runBulkAction(ActionUnsubscribe)
+		}),
+		widget.NewButton(tr("Snooze"), func() {
+			for _, notification := range bulkSelectedNotifications() {
+				snoozeNotification(notification, SNOOZE_DURATION)
+			}
+
+			clearBulkSelection()
+		}),
+		widget.NewButton(tr("Clear"), func() {
+			clearBulkSelection()
+		}),
+	)
+
+	bulkProgress = widget.NewProgressBar()
+	bulkProgress.Hide()
+
+	bulkBar = container.NewVBox(
+		container.NewHBox(bulkLabel, layout.NewSpacer(), bulkButtons),
+		bulkProgress,
+	)
+	bulkBar.Hide()
+
+	return bulkBar
+}
+
+func refreshBulkActionsUI() {
+	// ticked rows hidden by the filter are left out of the actions too
+	count := len(bulkSelectedNotifications())
+
+	if count == 0 && !bulkProgress.Visible() {
+		bulkBar.Hide()
+		return
+	}
+
+	bulkLabel.SetText(trn("%d selected", "%d selected", count))
+	bulkBar.Show()
+}
+
+// runBulkAction applies the action to every ticked notification, at most
+// BULK_PARALLELISM at a time. Ones that fail for lack of a connection go to
+// the outbox; the others that failed are reported.
+func runBulkAction(kind ActionKind) {
+	notifications := bulkSelectedNotifications()
+
+	if len(notifications) == 0 {
+		return
+	}
+
+	bulkButtons.Hide()
+	bulkProgress.Max = float64(len(notifications))
+	bulkProgress.SetValue(0)
+	bulkProgress.Show()
+
+	go func() {
+		var wg sync.WaitGroup
+		var mutex sync.Mutex
+		var failures []string
+
+		done := 0
+		semaphore := make(chan struct{}, BULK_PARALLELISM)
+
+		for _, notification := range notifications {
+			wg.Add(1)
+			semaphore <- struct{}{}
+
+			go func(notification *github.Notification) {
+				defer wg.Done()
+				defer func() { <-semaphore }()
+
+				ok, err := actionFunc(kind)(globalCtx, notification)
+
+				if !ok && !isPermanentError(err) {
+					enqueueOutbox(kind, notification)
+					ok = true
+				}
+
+				mutex.Lock()
+				done++
+				progress := done
+
+				if !ok {
+					failures = append(failures, fmt.Sprintf("%s: %s", notification.GetSubject().GetTitle(), err))
+				}
+				mutex.Unlock()
+
+				runOnUI(func() {
+					if ok {
+						setBulkSelected(notification.GetID(), false)
+						applyActionLocally(kind, notification)
+					}
+
+					bulkProgress.SetValue(float64(progress))
+				})
+			}(notification)
+		}
+
+		wg.Wait()
+
+		runOnUI(func() {
+			bulkProgress.Hide()
+			bulkButtons.Show()
+			refreshBulkActionsUI()
+			refreshNotifications()
+
+			if len(failures) != 0 {
+				dialog.ShowError(errors.New(trn("%[2]s failed for %[3]d of %[1]d notification:", "%[2]s failed for %[3]d of %[1]d notifications:",
+					len(notifications), actionMessage(kind), len(failures))+"\n"+strings.Join(failures, "\n")), window)
+			}
+		})
+	}()
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/google/go-github/v55/github"
+)
+
+func TestPruneBulkSelectionKeepsFilteredRows(t *testing.T) {
+	fake := useFakeGitHub(t, 0)
+	notifications := []*github.Notification{fake.notification(1), fake.notification(2), fake.notification(3)}
+
+	t.Cleanup(func() {
+		runOnUI(func() {
+			notificationStore.SetFilter(notificationFilter)
+			clearBulkSelection()
+		})
+		waitUI(t)
+	})
+
+	runOnUI(func() {
+		addNotifications(notifications, nil)
+		setBulkSelected("1", true)
+		setBulkSelected("2", true)
+
+		// a search that hides the second one
+		notificationStore.SetFilter(func(notification *github.Notification) bool {
+			return notification.GetID() != "2"
+		})
+	})
+	waitUI(t)
+
+	if !isBulkSelected("2") {
+		t.Fatal("row hidden by the filter was unticked")
+	}
+
+	if selected := bulkSelectedNotifications(); len(selected) != 1 {
+		t.Errorf("%d notifications acted on, want only the listed one", len(selected))
+	}
+
+	runOnUI(func() {
+		addNotifications([]*github.Notification{notifications[0], notifications[2]}, nil)
+	})
+	waitUI(t)
+
+	if isBulkSelected("2") {
+		t.Error("row no longer fetched is still ticked")
+	}
+
+	if !isBulkSelected("1") {
+		t.Error("row still fetched was unticked")
+	}
+}
@@ -30,3 +30,28 @@ var resourceSettingsPng = &fyne.StaticResource{
 	StaticContent: []byte(
 		"\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR\x00\x00\x000\x00\x00\x000\b\x06\x00\x00\x00W\x02\xf9\x87\x00\x00\x03\"IDAThC\xedZ\xb9n\x14A\x10]\x83\x00\v!\x83 \xc3\x16\x04>\xb8\xc9\xfd\x01\x10p\x05D\xf8\xc2K\x04\x12\xe2H\xf8\n\x12\x0e\t\xe1\x88\xe54\t\x04\x1c\t|\x009ؖ\x0f\b@@\x06\x02dY\xd8\x16\xc7{\xa3iiw<=U\xdd\xd3b\xb7%Fz\x9a\x9d\xe9\xae\xeaz\xd5\xdd\xd5\xd5=\xdbV\t\x7f\x1d\x80ʧ\xc0\xba\x8c\xea\x9fx>\x06\xbc\f\xd9d[He\xa9.\x1a\x9a5\xde4\xb3\x88\x1f\xed!\xdb\fM`\r\x8c[\x12\f\\\x8b\xf2\xe5P$B\x13\xd8\bþ\t\xc6mB\xf9\xf7V%\xb0\v\x86M\t\xc6\xedQ\xd4Q\xf3\xd3\xf4\xc0ih\xbb\n,\x00\xfd\xc0L\x81\xf6\xa3({\"\xb4Ή\xccIn\xbbv\xa0\xe0\x15\xb0\x1e\xb8\x00\x8c\x15\xe9\x93\b\x9c\x83\U00035302\x93x\xbe\x9b\xa3\x94\xc3\xe7\nP\x15\b\xd4P~\x11\xc8\x1bF#x\x7f'#\x7f\x1e\xcf\xd7m:\x8b\b\xe4\x19o\xf4ЈS\xe9\xc3a\xdco\x00\xdb\x04ó\xc5\x1f\xf0\xe2,\xf0,-\xb8U@\xdeJ\xc2F\x80\xc3\xe6\xa6`\xd0\x1cʷ\x00\x9b\x1d\r\xcfV\xff\x8a\x17_\x80^A\xcf\x19\x94\xaf\x18N6\x02E\xb1\xbc\xa4\xbd\xde\xe2\xb9k\x88\x8d\x00=RֳޖZ\x04\xd9S\xec\xf1\x86\xcbF\x80\x91`:\xb4\x05%\xf5\xed\x84\xfc\x8a\bX4\x89\x19mn\x97l4\x94\xb8-\xf2U\xa40Z\x83\x05\xa3\xa1\xac\xf0\xd4C'Vm\xb2\x12\x01\xca\xcd*\"\x84\xa7m\xa2\x18#]_Q-\r\x81fN\xe8܉[OH\"p\b\x95\x9f\x8b~j\xac\xf0\t\x8f\x97R\xb9\xf9\xb4h\x03\xee\\\xf0.\x03\x9d\x8e\xfa\x8e\x14\xd9 \x11x\x0fa\xed\n\xfb\au\x87\x80q\xc1\xc0\x01\x94\xdf\a\xa4\xb6\x8d\x1a\xae\xd8\xdb}\xe6\x80&56zi\xfc>`R\xe9]f\xa4o\x1cHXS\xf0z/p3\xc2\fp+\xd0\x03\x1c\a\xaaJ\x83\x06\x15\x9eϪbO<Pꯡ\xdec\xe0-\xf0\x19`f\x9cl\x8aH\xc0\xb6\x87U\xea\xaep\xccwi+g\xea}ĳ\xeb\x9c0*\x92=6\t\x94\xcd{\xe8ɇ\x9e\x04Nx\xf4\\}S\x8b$\xc0\xf1[\xe6\xe2\\\xf9᩠\x03r\xa5\xb6\x97!\b\xac\x86\x11\xbf=\t\xac\x82\xdc/O\xd9D\xec?\x018\xa1\xe9C(\xfaI|\x10^\xe4I\x82\xed4M\x1a\xa2\xcd\n\xa3ܡ%a\xd4\\f!c\\\xee\x06\\\x162\x9fP\xea\x12B\x99R?\x02\xde\x01tX\xc3Bf\xf3\xb0k*\xb1\x1f\x8a&\xa4\xeeJ\xcb\xf7\xe2\xfe:\r\"\x1a\x11U*\x91\xa7\xc85\x99\x1b\x86\x12)=`\xdaq\xcf\xc1x\xefd\x8e\x84\x98\x02\x9bs\x1b\x8d\xa7X\xa7\xa5\xd2i\x1a\x14\xf5\x86\x86\x04\xa2\xdeR\xd6@ \xdaM}\xd4\xc7*\xd1\x1fl5s\xe2ڢ\x9d\xd3\xd1b\xd9\xfcH\x1br]\xea9\x1d\xeeF\x7f\xbcN\xcfD\xfd\x81\xc3tmԟ\x98\f\x89\xa8?\xf2\xb9L2\xd6\xdd\rH\x87[\xff\xfc3\xab\v\tM\n\xde\xd2\x1f\xba\xa3\xff\xab\x01{+\xea?{\x90\x80m\x8f\x9d\xeca\x81\x17.cR\xaa\xfb\x17\xf6㲨9\x15I\x85\x00\x00\x00\x00IEND\xaeB`\x82"),
 }
+var resourceTypeCommitSvg = &fyne.StaticResource{
+	StaticName: "type-commit.svg",
+	StaticContent: []byte(
+		"<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"16\" height=\"16\" viewBox=\"0 0 16 16\"><path fill=\"#000000\" fill-rule=\"evenodd\" d=\"M8 4.25a3.75 3.75 0 0 1 3.675 3H15.25a.75.75 0 0 1 0 1.5h-3.575a3.75 3.75 0 0 1-7.35 0H.75a.75.75 0 0 1 0-1.5h3.575A3.75 3.75 0 0 1 8 4.25zm0 1.5a2.25 2.25 0 1 0 0 4.5 2.25 2.25 0 0 0 0-4.5z\"/></svg>\n"),
+}
+var resourceTypeDiscussionSvg = &fyne.StaticResource{
+	StaticName: "type-discussion.svg",
+	StaticContent: []byte(
+		"<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"16\" height=\"16\" viewBox=\"0 0 16 16\"><path fill=\"#000000\" fill-rule=\"evenodd\" d=\"M2.75 1.5h10.5c.966 0 1.75.784 1.75 1.75v7.5a1.75 1.75 0 0 1-1.75 1.75H8.06l-3.03 2.78a.75.75 0 0 1-1.26-.55V12.5H2.75A1.75 1.75 0 0 1 1 10.75v-7.5c0-.966.784-1.75 1.75-1.75zm0 1.5a.25.25 0 0 0-.25.25v7.5c0 .138.112.25.25.25h1.77a.75.75 0 0 1 .75.75v1.03l2.03-1.86a.75.75 0 0 1 .51-.2h5.44a.25.25 0 0 0 .25-.25v-7.5a.25.25 0 0 0-.25-.25z\"/></svg>\n"),
+}
+var resourceTypeIssueSvg = &fyne.StaticResource{
+	StaticName: "type-issue.svg",
+	StaticContent: []byte(
+		"<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"16\" height=\"16\" viewBox=\"0 0 16 16\"><path fill=\"#000000\" fill-rule=\"evenodd\" d=\"M8 1a7 7 0 1 1 0 14A7 7 0 0 1 8 1zm0 1.5a5.5 5.5 0 1 0 0 11 5.5 5.5 0 0 0 0-11zM8 6.5a1.5 1.5 0 1 1 0 3 1.5 1.5 0 0 1 0-3z\"/></svg>\n"),
+}
+var resourceTypePullRequestSvg = &fyne.StaticResource{
+	StaticName: "type-pull-request.svg",
+	StaticContent: []byte(
+		"<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"16\" height=\"16\" viewBox=\"0 0 16 16\"><path fill=\"#000000\" fill-rule=\"evenodd\" d=\"M4 1a2.25 2.25 0 0 1 .75 4.372v5.256a2.25 2.25 0 1 1-1.5 0V5.372A2.25 2.25 0 0 1 4 1zm0 1.5a.75.75 0 1 0 0 1.5.75.75 0 0 0 0-1.5zm0 9.5a.75.75 0 1 0 0 1.5.75.75 0 0 0 0-1.5zM8.22.97a.75.75 0 0 1 1.06 0l1.5 1.5a.75.75 0 0 1 0 1.06l-1.5 1.5a.75.75 0 1 1-1.06-1.06l.22-.22H7.5v-.75V3.75h.94l-.22-.22a.75.75 0 0 1 0-1.06zM7.5 2.25h3.75c.966 0 1.75.784 1.75 1.75v6.628a2.25 2.25 0 1 1-1.5 0V4a.25.25 0 0 0-.25-.25H7.5zm4.5 9.75a.75.75 0 1 0 0 1.5.75.75 0 0 0 0-1.5z\"/></svg>\n"),
+}
+var resourceTypeReleaseSvg = &fyne.StaticResource{
+	StaticName: "type-release.svg",
+	StaticContent: []byte(
+		"<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"16\" height=\"16\" viewBox=\"0 0 16 16\"><path fill=\"#000000\" fill-rule=\"evenodd\" d=\"M1 2.75C1 1.784 1.784 1 2.75 1h4.586c.464 0 .909.184 1.237.513l6.25 6.25a1.75 1.75 0 0 1 0 2.474l-4.586 4.586a1.75 1.75 0 0 1-2.474 0l-6.25-6.25A1.75 1.75 0 0 1 1 7.336zm1.75-.25a.25.25 0 0 0-.25.25v4.586c0 .066.026.13.073.177l6.25 6.25a.25.25 0 0 0 .354 0l4.586-4.586a.25.25 0 0 0 0-.354l-6.25-6.25a.25.25 0 0 0-.177-.073zM5 4a1 1 0 1 1 0 2 1 1 0 0 1 0-2z\"/></svg>\n"),
+}
@@ -0,0 +1,661 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2/dialog"
+	"github.com/fsnotify/fsnotify"
+	"gopkg.in/yaml.v3"
+)
+
+const CONFIG_ENV_PREFIX = "GITHUB_NOTIFY_"
+const CONFIG_RELOAD_DELAY time.Duration = time.Millisecond * 300
+
+// Config is everything that can be set in the config file. The settings
+// panel edits the same model and writes it back to the file.
+type Config struct {
+	Accounts   []AccountConfig    `yaml:"accounts"`
+	Polling    PollingConfig      `yaml:"polling"`
+	Filters    FilterConfig       `yaml:"filters,omitempty"`
+	DND        DNDConfig          `yaml:"dnd,omitempty"`
+	Hooks      []NotificationHook `yaml:"hooks,omitempty"`
+	Keymap     map[string]string  `yaml:"keymap,omitempty"`
+	Appearance AppearanceConfig   `yaml:"appearance,omitempty"`
+	Startup    StartupConfig      `yaml:"startup,omitempty"`
+}
+
+// StartupConfig starts the app minimised on login, from an XDG autostart
+// entry or, with Systemd, a systemd user unit. Minimized keeps the window
+// hidden in the tray whenever the app starts.
+type StartupConfig struct {
+	Autostart bool `yaml:"autostart"`
+	Systemd   bool `yaml:"systemd,omitempty"`
+	Minimized bool `yaml:"minimized,omitempty"`
+}
+
+// AppearanceConfig picks the theme: Theme is "system", "light" or "dark",
+// Accent a name from ACCENT_COLORS or "#rrggbb".
+type AppearanceConfig struct {
+	// Language is a locale like "de" or "en_US". The environment decides
+	// when it is empty.
+	Language     string `yaml:"language,omitempty"`
+	Theme        string `yaml:"theme,omitempty"`
+	Accent       string `yaml:"accent,omitempty"`
+	HighContrast bool   `yaml:"high_contrast,omitempty"`
+	// TextScale is a percentage, 100 being the normal text size.
+	TextScale int `yaml:"text_scale,omitempty"`
+	// TitleLines is how many lines a notification title may wrap over.
+	TitleLines int `yaml:"title_lines,omitempty"`
+	// CompactWindow shows a small borderless window that hides when the
+	// app loses focus. It takes effect on restart.
+	CompactWindow bool `yaml:"compact_window,omitempty"`
+}
+
+type AccountConfig struct {
+	Name  string `yaml:"name"`
+	Token string `yaml:"token"`
+}
+
+type PollingConfig struct {
+	// Interval is in seconds.
+	Interval     int  `yaml:"interval"`
+	LookbackDays int  `yaml:"lookback_days"`
+	Adaptive     bool `yaml:"adaptive"`
+}
+
+// FilterConfig limits which notifications are listed. Repositories are
+// owner/name patterns, e.g. "my-org/*".
+type FilterConfig struct {
+	Reasons             []string `yaml:"reasons,omitempty"`
+	Repositories        []string `yaml:"repositories,omitempty"`
+	ExcludeRepositories []string `yaml:"exclude_repositories,omitempty"`
+}
+
+// DNDConfig silences desktop notifications between Start and End, given as
+// "15:04". The range may wrap past midnight.
+type DNDConfig struct {
+	Enabled bool   `yaml:"enabled"`
+	Start   string `yaml:"start,omitempty"`
+	End     string `yaml:"end,omitempty"`
+}
+
+// appConfig holds the config as read from the file and the effective one,
+// with environment overrides applied on top. fileErr is set while the file
+// cannot be used, and the last good config is in effect instead.
+var appConfig = struct {
+	sync.RWMutex
+	file      Config
+	effective Config
+	fileErr   error
+}{
+	file:      defaultConfig(),
+	effective: defaultConfig(),
+}
+
+func defaultConfig() Config {
+	return Config{
+		Polling: PollingConfig{
+			Interval:     int(REPEAT_TIME / time.Second),
+			LookbackDays: DAY_OLDER,
+		},
+	}
+}
+
+func configPath() string {
+	if path := os.Getenv(CONFIG_ENV_PREFIX + "CONFIG"); path != "" {
+		return path
+	}
+
+	dir, err := os.UserConfigDir()
+
+	if err != nil {
+		dir = "."
+	}
+
+	return filepath.Join(dir, APP_ID, "config.yaml")
+}
+
+func currentConfig() Config {
+	appConfig.RLock()
+	defer appConfig.RUnlock()
+
+	return appConfig.effective
+}
+
+func fileConfig() Config {
+	appConfig.RLock()
+	defer appConfig.RUnlock()
+
+	return appConfig.file
+}
+
+func githubToken() string {
+	config := currentConfig()
+
+	if len(config.Accounts) == 0 {
+		return ""
+	}
+
+	return config.Accounts[0].Token
+}
+
+// lastGoodConfigPath is a copy of the config file as it was last read or
+// saved without errors.
+func lastGoodConfigPath() string {
+	return configPath() + ".last-good"
+}
+
+// configFileError is why the config file cannot be used, or nil.
+func configFileError() error {
+	appConfig.RLock()
+	defer appConfig.RUnlock()
+
+	return appConfig.fileErr
+}
+
+func setConfigFileError(err error) {
+	appConfig.Lock()
+	defer appConfig.Unlock()
+
+	appConfig.fileErr = err
+}
+
+// loadConfig reads the config file, or creates it from the preferences of
+// earlier versions when there is none yet. A file that cannot be used is
+// left as it is and the last good copy, or else the defaults, is used with
+// the environment overrides; the error is returned to be shown.
+func loadConfig() error {
+	path := configPath()
+	config, err := readConfigFile(path)
+
+	if errors.Is(err, os.ErrNotExist) {
+		config = configFromPreferences()
+
+		if err := writeConfigFile(path, config); err != nil {
+			slog.Warn("Config file not created", "path", path, "err", err)
+		}
+
+		err = nil
+	}
+
+	if err != nil {
+		setConfigFileError(err)
+
+		fallback, lastGoodErr := readConfigFile(lastGoodConfigPath())
+
+		if lastGoodErr != nil {
+			fallback = defaultConfig()
+		}
+
+		return errors.Join(err, setConfig(fallback))
+	}
+
+	if err := setConfig(config); err != nil {
+		return err
+	}
+
+	saveLastGoodConfig(config)
+
+	return nil
+}
+
+func saveLastGoodConfig(config Config) {
+	if err := writeConfigFile(lastGoodConfigPath(), config); err != nil {
+		slog.Warn("Last good config not saved", "err", err)
+	}
+}
+
+func readConfigFile(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+
+	if err != nil {
+		return Config{}, err
+	}
+
+	config := defaultConfig()
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	if err := decoder.Decode(&config); err != nil && err != io.EOF {
+		return Config{}, fmt.Errorf("%s: %w", path, err)
+	}
+
+	if err := validateConfig(config); err != nil {
+		return Config{}, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return config, nil
+}
+
+func writeConfigFile(path string, config Config) error {
+	data, err := yaml.Marshal(config)
+
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+
+	// write to a temporary file first so a reload never sees half a file
+	tmp := path + ".tmp"
+
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, path)
+}
+
+// saveConfig validates config, writes it to the file and applies it. A file
+// that could not be read is replaced too, so ask first, see configFileError.
+func saveConfig(config Config) error {
+	if err := validateConfig(config); err != nil {
+		return err
+	}
+
+	if err := writeConfigFile(configPath(), config); err != nil {
+		return err
+	}
+
+	setConfigFileError(nil)
+	saveLastGoodConfig(config)
+
+	return setConfig(config)
+}
+
+func setConfig(config Config) error {
+	effective := config
+	effective.Accounts = append([]AccountConfig(nil), config.Accounts...)
+
+	if err := applyEnvOverrides(&effective); err != nil {
+		return err
+	}
+
+	if err := validateConfig(effective); err != nil {
+		return fmt.Errorf("environment: %w", err)
+	}
+
+	appConfig.Lock()
+	previous := appConfig.effective
+	appConfig.file = config
+	appConfig.effective = effective
+	appConfig.Unlock()
+
+	setLogSecrets(config, effective)
+
+	if notificationStore != nil && !reflect.DeepEqual(previous, effective) {
+		runOnUI(func() {
+			applyConfigChange(previous, effective)
+		})
+	}
+
+	return nil
+}
+
+// applyConfigChange restarts or refreshes whatever the change affects.
+func applyConfigChange(previous Config, config Config) {
+	if previous.Appearance != config.Appearance {
+		setLocale(config.Appearance.Language)
+		applyAppearance(config.Appearance)
+		notificationListComponent.Refresh()
+	}
+
+	if previous.Startup != config.Startup {
+		if err := setAutostart(config.Startup); err != nil {
+			slog.Error("Autostart not updated", "err", err)
+			dialog.ShowError(fmt.Errorf("%s\n%w", tr("Starting on login could not be updated:"), err), window)
+		}
+	}
+
+	loadKeymap()
+	notificationStore.Refilter()
+
+	if !reflect.DeepEqual(previous.Accounts, config.Accounts) {
+		if githubToken() == "" {
+			taskSupervisor.Stop(NOTIFY_TASK)
+			return
+		}
+
+		startNotifyLoop()
+		return
+	}
+
+	if !reflect.DeepEqual(previous.Polling, config.Polling) {
+		refreshNotifications()
+	}
+}
+
+// applyEnvOverrides lets GITHUB_NOTIFY_TOKEN, GITHUB_NOTIFY_POLL_INTERVAL,
+// GITHUB_NOTIFY_LOOKBACK_DAYS, GITHUB_NOTIFY_ADAPTIVE and GITHUB_NOTIFY_DND
+// ("22:00-08:00" or "off") take precedence over the file.
+func applyEnvOverrides(config *Config) error {
+	if token, ok := os.LookupEnv(CONFIG_ENV_PREFIX + "TOKEN"); ok {
+		if len(config.Accounts) == 0 {
+			config.Accounts = []AccountConfig{{Name: "default"}}
+		}
+
+		config.Accounts[0].Token = token
+	}
+
+	for name, value := range map[string]*int{
+		"POLL_INTERVAL": &config.Polling.Interval,
+		"LOOKBACK_DAYS": &config.Polling.LookbackDays,
+	} {
+		text, ok := os.LookupEnv(CONFIG_ENV_PREFIX + name)
+
+		if !ok {
+			continue
+		}
+
+		number, err := strconv.Atoi(text)
+
+		if err != nil {
+			return fmt.Errorf("%s%s: %s", CONFIG_ENV_PREFIX, name, tr("%q is not a number", text))
+		}
+
+		*value = number
+	}
+
+	if text, ok := os.LookupEnv(CONFIG_ENV_PREFIX + "ADAPTIVE"); ok {
+		adaptive, err := strconv.ParseBool(text)
+
+		if err != nil {
+			return fmt.Errorf("%sADAPTIVE: %s", CONFIG_ENV_PREFIX, tr("%q is not true or false", text))
+		}
+
+		config.Polling.Adaptive = adaptive
+	}
+
+	if text, ok := os.LookupEnv(CONFIG_ENV_PREFIX + "DND"); ok {
+		if text == "off" {
+			config.DND = DNDConfig{}
+		} else {
+			start, end, ok := strings.Cut(text, "-")
+
+			if !ok {
+				return fmt.Errorf("%sDND: %s", CONFIG_ENV_PREFIX, tr("%q is not a range like 22:00-08:00", text))
+			}
+
+			config.DND = DNDConfig{Enabled: true, Start: start, End: end}
+		}
+	}
+
+	return nil
+}
+
+// validateConfig reports every problem at once, each prefixed with the
+// path of the offending setting.
+func validateConfig(config Config) error {
+	var errs []error
+
+	for i, account := range config.Accounts {
+		if account.Name == "" {
+			errs = append(errs, fmt.Errorf("accounts[%d].name: %s", i, tr("must not be empty")))
+		}
+	}
+
+	if config.Polling.Interval < int(MIN_REPEAT_TIME/time.Second) {
+		errs = append(errs, fmt.Errorf("polling.interval: %s", tr("must be at least %d seconds, got %d",
+			int(MIN_REPEAT_TIME/time.Second), config.Polling.Interval)))
+	}
+
+	if config.Polling.LookbackDays < 1 {
+		errs = append(errs, fmt.Errorf("polling.lookback_days: %s", tr("must be at least 1, got %d", config.Polling.LookbackDays)))
+	}
+
+	for _, pattern := range config.Filters.Repositories {
+		if _, err := path.Match(pattern, ""); err != nil {
+			errs = append(errs, fmt.Errorf("filters.repositories: %s", tr("invalid pattern %q", pattern)))
+		}
+	}
+
+	for _, pattern := range config.Filters.ExcludeRepositories {
+		if _, err := path.Match(pattern, ""); err != nil {
+			errs = append(errs, fmt.Errorf("filters.exclude_repositories: %s", tr("invalid pattern %q", pattern)))
+		}
+	}
+
+	if config.DND.Enabled {
+		if _, err := time.Parse("15:04", config.DND.Start); err != nil {
+			errs = append(errs, fmt.Errorf("dnd.start: %s", tr("%q is not a time like 22:00", config.DND.Start)))
+		}
+
+		if _, err := time.Parse("15:04", config.DND.End); err != nil {
+			errs = append(errs, fmt.Errorf("dnd.end: %s", tr("%q is not a time like 08:00", config.DND.End)))
+		}
+	}
+
+	switch config.Appearance.Theme {
+	case "", "system", "light", "dark":
+	default:
+		errs = append(errs, fmt.Errorf("appearance.theme: %s", tr("%q is not system, light or dark", config.Appearance.Theme)))
+	}
+
+	if scale := config.Appearance.TextScale; scale != 0 && (scale < 50 || scale > 300) {
+		errs = append(errs, fmt.Errorf("appearance.text_scale: %s", tr("must be between 50 and 300 percent, got %d", scale)))
+	}
+
+	if lines := config.Appearance.TitleLines; lines < 0 || lines > MAX_TITLE_LINES {
+		errs = append(errs, fmt.Errorf("appearance.title_lines: %s", tr("must be between 1 and %d, or 0 for one line, got %d", MAX_TITLE_LINES, lines)))
+	}
+
+	if language := config.Appearance.Language; language != "" && !isBundledLanguage(language) {
+		errs = append(errs, fmt.Errorf("appearance.language: %s", tr("%q is not one of %s", language, strings.Join(languageCodes(), ", "))))
+	}
+
+	if config.Appearance.Accent != "" {
+		if _, err := parseAccent(config.Appearance.Accent); err != nil {
+			errs = append(errs, fmt.Errorf("appearance.accent: %w", err))
+		}
+	}
+
+	if err := validateHooks(config.Hooks); err != nil {
+		errs = append(errs, fmt.Errorf("hooks: %w", err))
+	}
+
+	if _, err := resolveKeymap(config.Keymap); err != nil {
+		errs = append(errs, fmt.Errorf("keymap: %w", err))
+	}
+
+	return errors.Join(errs...)
+}
+
+// configFromPreferences builds a config from the settings earlier versions
+// kept in the app preferences.
+func configFromPreferences() Config {
+	preferences := notifierApp.Preferences()
+	config := defaultConfig()
+
+	if token := preferences.String("github_token"); token != "" {
+		config.Accounts = []AccountConfig{{Name: "default", Token: token}}
+	}
+
+	config.Polling.Interval = preferences.IntWithFallback("poll_interval", config.Polling.Interval)
+	config.Polling.LookbackDays = preferences.IntWithFallback("lookback_days", config.Polling.LookbackDays)
+	config.Polling.Adaptive = preferences.BoolWithFallback("adaptive_polling", false)
+
+	if config.Polling.Interval < int(MIN_REPEAT_TIME/time.Second) {
+		config.Polling.Interval = int(MIN_REPEAT_TIME / time.Second)
+	}
+
+	if config.Polling.LookbackDays < 1 {
+		config.Polling.LookbackDays = 1
+	}
+
+	if hooks, err := parseHooks(preferences.String("notification_hooks")); err == nil {
+		config.Hooks = hooks
+	}
+
+	if actionKeys, err := parseKeymap(preferences.String("keymap")); err == nil {
+		config.Keymap = keymapOverrides(actionKeys)
+	}
+
+	return config
+}
+
+// watchConfig reloads the config file whenever it changes on disk. The
+// directory is watched rather than the file since editors often replace it.
+func watchConfig() {
+	watcher, err := fsnotify.NewWatcher()
+
+	if err != nil {
+		slog.Warn("Config watcher", "err", err)
+		return
+	}
+
+	path := configPath()
+
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		slog.Warn("Config watcher", "err", err)
+		watcher.Close()
+		return
+	}
+
+	go func() {
+		var reload *time.Timer
+
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+
+				if filepath.Clean(event.Name) != filepath.Clean(path) || event.Has(fsnotify.Chmod) {
+					continue
+				}
+
+				// editors save in several steps, wait for the last one
+				if reload != nil {
+					reload.Stop()
+				}
+
+				reload = time.AfterFunc(CONFIG_RELOAD_DELAY, reloadConfig)
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+
+				slog.Warn("Config watcher", "err", err)
+			}
+		}
+	}()
+}
+
+func reloadConfig() {
+	config, err := readConfigFile(configPath())
+
+	if err == nil {
+		err = setConfig(config)
+	}
+
+	if errors.Is(err, os.ErrNotExist) {
+		return
+	}
+
+	if err != nil {
+		setConfigFileError(err)
+		slog.Error("Config not reloaded", "path", configPath(), "err", err)
+
+		runOnUI(func() {
+			dialog.ShowError(fmt.Errorf("%s\n%w", tr("The config file was not reloaded, the previous settings are kept:"), err), window)
+		})
+		return
+	}
+
+	setConfigFileError(nil)
+	saveLastGoodConfig(config)
+	slog.Info("Config reloaded", "path", configPath())
+}
+
+func hooksJSONText(hooks []NotificationHook) string {
+	if len(hooks) == 0 {
+		return ""
+	}
+
+	hooksJSON, err := json.MarshalIndent(hooks, "", "  ")
+
+	if err != nil {
+		return ""
+	}
+
+	return string(hooksJSON)
+}
+
+func titleLines() int {
+	if lines := currentConfig().Appearance.TitleLines; lines > 0 {
+		return lines
+	}
+
+	return 1
+}
+
+func isDoNotDisturb(now time.Time) bool {
+	dnd := currentConfig().DND
+
+	if !dnd.Enabled {
+		return false
+	}
+
+	start, err := time.Parse("15:04", dnd.Start)
+
+	if err != nil {
+		return false
+	}
+
+	end, err := time.Parse("15:04", dnd.End)
+
+	if err != nil {
+		return false
+	}
+
+	minutes := now.Hour()*60 + now.Minute()
+	from := start.Hour()*60 + start.Minute()
+	to := end.Hour()*60 + end.Minute()
+
+	if from <= to {
+		return minutes >= from && minutes < to
+	}
+
+	return minutes >= from || minutes < to
+}
+
+func matchesConfigFilters(repository string, reason string) bool {
+	filters := currentConfig().Filters
+
+	if !matchesAny(filters.Reasons, reason) {
+		return false
+	}
+
+	if len(filters.Repositories) != 0 && !matchesPattern(filters.Repositories, repository) {
+		return false
+	}
+
+	return !matchesPattern(filters.ExcludeRepositories, repository)
+}
+
+func matchesPattern(patterns []string, value string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(strings.ToLower(pattern), strings.ToLower(value)); ok {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadConfigKeepsBrokenFile(t *testing.T) {
+	useFakeGitHub(t, 0)
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	t.Setenv(CONFIG_ENV_PREFIX+"CONFIG", path)
+	t.Setenv(CONFIG_ENV_PREFIX+"TOKEN", "env-token")
+
+	lastGood := defaultConfig()
+	lastGood.Polling.Interval = 120
+
+	if err := writeConfigFile(lastGoodConfigPath(), lastGood); err != nil {
+		t.Fatal(err)
+	}
+
+	broken := []byte("polling: [unclosed\n")
+
+	if err := os.WriteFile(path, broken, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := loadConfig(); err == nil {
+		t.Fatal("broken file loaded without an error")
+	}
+
+	if configFileError() == nil {
+		t.Error("broken file not reported")
+	}
+
+	config := currentConfig()
+
+	if config.Polling.Interval != 120 {
+		t.Errorf("polling.interval = %d, want the last good 120", config.Polling.Interval)
+	}
+
+	if githubToken() != "env-token" {
+		t.Errorf("environment overrides not applied, token %q", githubToken())
+	}
+
+	if data, _ := os.ReadFile(path); string(data) != string(broken) {
+		t.Error("broken file was overwritten")
+	}
+
+	os.Remove(lastGoodConfigPath())
+
+	if err := loadConfig(); err == nil {
+		t.Fatal("broken file loaded without an error")
+	}
+
+	if config := currentConfig(); config.Polling.Interval != defaultConfig().Polling.Interval {
+		t.Errorf("polling.interval = %d without a last good copy, want the default", config.Polling.Interval)
+	}
+
+	if err := saveConfig(lastGood); err != nil {
+		t.Fatal(err)
+	}
+
+	if configFileError() != nil {
+		t.Error("file still reported as broken after saving")
+	}
+
+	waitUI(t)
+}
+
+func TestValidateConfigIsTranslated(t *testing.T) {
+	setLocale("de")
+	defer setLocale("en")
+
+	config := defaultConfig()
+	config.Polling.LookbackDays = 0
+	config.Keymap = map[string]string{"launch": "x"}
+
+	err := validateConfig(config)
+
+	if err == nil {
+		t.Fatal("invalid config accepted")
+	}
+
+	for _, want := range []string{"polling.lookback_days: muss mindestens 1 sein, ist 0", `keymap: unbekannte Aktion "launch"`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("validateConfig = %q, want %q", err, want)
+		}
+	}
+}
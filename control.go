@@ -0,0 +1,138 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+)
+
+// The control socket lets a second invocation talk to the running app, e.g.
+// `notify --toggle` bound to a desktop-wide hotkey in the OS settings.
+
+func controlSocketPath() string {
+	dir, err := os.UserCacheDir()
+
+	if err != nil {
+		dir = os.TempDir()
+	}
+
+	return filepath.Join(dir, APP_ID, "control.sock")
+}
+
+func sendControlCommand(command string) error {
+	conn, err := net.Dial("unix", controlSocketPath())
+
+	if err != nil {
+		return fmt.Errorf("%s: %w", tr("app is not running"), err)
+	}
+
+	defer conn.Close()
+
+	_, err = fmt.Fprintln(conn, command)
+
+	return err
+}
+
+// forwardToRunningInstance sends command to the instance listening on the
+// control socket, if there is one. A socket nobody listens on is left behind
+// by a crashed instance and removed; any other error leaves it alone.
+func forwardToRunningInstance(command string) bool {
+	path := controlSocketPath()
+
+	conn, err := net.Dial("unix", path)
+
+	if err == nil {
+		defer conn.Close()
+
+		if command != "" {
+			_, _ = fmt.Fprintln(conn, command)
+		}
+
+		return true
+	}
+
+	if errors.Is(err, syscall.ECONNREFUSED) {
+		_ = os.Remove(path)
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		slog.Warn("Control socket", "err", err)
+	}
+
+	return false
+}
+
+func listenControlSocket() {
+	path := controlSocketPath()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		slog.Warn("Control socket", "err", err)
+		return
+	}
+
+	listener, err := net.Listen("unix", path)
+
+	if err != nil {
+		slog.Warn("Control socket", "err", err)
+		return
+	}
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+
+			if err != nil {
+				slog.Warn("Control socket", "err", err)
+				return
+			}
+
+			go handleControlConn(conn)
+		}
+	}()
+}
+
+func handleControlConn(conn net.Conn) {
+	defer conn.Close()
+
+	scanner := bufio.NewScanner(conn)
+
+	for scanner.Scan() {
+		switch strings.TrimSpace(scanner.Text()) {
+		case "toggle":
+			runOnUI(toggleWindow)
+		case "show":
+			runOnUI(showWindow)
+		case "hide":
+			runOnUI(hideWindow)
+		case "refresh":
+			refreshNotifications()
+		}
+	}
+}
+
+func showWindow() {
+	setWindowVisible(true)
+	window.Show()
+	window.RequestFocus()
+	runOnUI(restoreLastView)
+}
+
+func hideWindow() {
+	saveWindowState()
+
+	setWindowVisible(false)
+	window.Hide()
+}
+
+func toggleWindow() {
+	if isWindowVisible() {
+		hideWindow()
+	} else {
+		showWindow()
+	}
+}
@@ -0,0 +1,310 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/google/go-github/v55/github"
+)
+
+const DETAIL_PANE_OFFSET float64 = 0.45
+const DETAIL_COMMENTS int = 5
+
+var notificationSplit *container.Split
+var detailPane *fyne.Container
+
+func addDetailPaneUI(list fyne.CanvasObject) *container.Split {
+	detailPane = container.NewStack()
+	detailPane.Hide()
+
+	notificationSplit = container.NewHSplit(list, detailPane)
+	notificationSplit.Offset = 1
+
+	return notificationSplit
+}
+
+// detailView holds the widgets of the open detail pane. While the same
+// notification stays open they are updated in place, so the comments are not
+// fetched again and the scroll position and reply are kept.
+var detailView = struct {
+	sync.Mutex
+	notification *github.Notification
+	title        *widget.Label
+	subtitle     *widget.Label
+	info         *widget.Label
+	body         *widget.RichText
+	bodyMarkdown string
+}{}
+
+func currentDetailNotification() *github.Notification {
+	detailView.Lock()
+	defer detailView.Unlock()
+
+	return detailView.notification
+}
+
+func showNotificationDetail(notification *github.Notification) {
+	if currentDetailNotificationID() == notification.GetID() && detailPane.Visible() {
+		updateNotificationDetail(notification)
+		return
+	}
+
+	setDetailNotificationID(notification.GetID())
+
+	title := widget.NewLabel("")
+	title.TextStyle.Bold = true
+	title.Wrapping = fyne.TextWrapWord
+
+	subtitle := widget.NewLabel("")
+	subtitle.Wrapping = fyne.TextWrapWord
+
+	info := widget.NewLabel("")
+	info.Wrapping = fyne.TextWrapWord
+
+	body := widget.NewRichTextFromMarkdown("")
+	body.Wrapping = fyne.TextWrapWord
+
+	comments := widget.NewRichTextFromMarkdown("")
+	comments.Wrapping = fyne.TextWrapWord
+
+	detailView.Lock()
+	detailView.title = title
+	detailView.subtitle = subtitle
+	detailView.info = info
+	detailView.body = body
+	detailView.bodyMarkdown = ""
+	detailView.Unlock()
+
+	updateNotificationDetail(notification)
+
+	go func() {
+		markdown := fetchRecentCommentsMarkdown(globalCtx, notification)
+
+		runOnUI(func() {
+			if currentDetailNotificationID() == notification.GetID() {
+				comments.ParseMarkdown(markdown)
+			}
+		})
+	}()
+
+	// the buttons act on the latest update of the notification
+	actions := container.NewHBox(
+		widget.NewButtonWithIcon(tr("Read"), theme.VisibilityIcon(), func() {
+			readNotificationAction(currentDetailNotification())
+		}),
+		widget.NewButtonWithIcon(tr("Done"), theme.ConfirmIcon(), func() {
+			doneNotificationAction(currentDetailNotification())
+		}),
+		widget.NewButtonWithIcon(tr("Open"), theme.ComputerIcon(), func() {
+			openURLInBrowser(notificationHTMLURL(currentDetailNotification()))
+		}),
+		widget.NewButtonWithIcon(tr("Unsubscribe"), theme.VolumeMuteIcon(), func() {
+			unsubscribeNotificationAction(currentDetailNotification())
+		}),
+		layout.NewSpacer(),
+		widget.NewButtonWithIcon("", theme.CancelIcon(), func() {
+			notificationListComponent.UnselectAll()
+		}),
+	)
+
+	content := container.NewVBox(
+		title,
+		subtitle,
+		info,
+		widget.NewSeparator(),
+		body,
+		widget.NewSeparator(),
+		comments,
+		addReplyUI(notification),
+	)
+
+	detailPane.Objects = []fyne.CanvasObject{
+		container.NewBorder(actions, nil, nil, nil, container.NewVScroll(content)),
+	}
+	detailPane.Show()
+	detailPane.Refresh()
+
+	if notificationSplit.Offset == 1 {
+		notificationSplit.SetOffset(DETAIL_PANE_OFFSET)
+	}
+
+	if size := window.Canvas().Size(); size.Width < 700 && !compactWindow {
+		setDetailWidening(size.Width, 800)
+		window.Resize(fyne.NewSize(800, size.Height))
+	}
+}
+
+// updateNotificationDetail shows the latest state of the open notification
+// and its subject details in the existing widgets.
+func updateNotificationDetail(notification *github.Notification) {
+	detailView.Lock()
+	detailView.notification = notification
+	title, subtitle, info, body := detailView.title, detailView.subtitle, detailView.info, detailView.body
+	detailView.Unlock()
+
+	title.SetText(notification.GetSubject().GetTitle())
+	subtitle.SetText(fmt.Sprintf("%s · %s · %s",
+		notification.GetRepository().GetFullName(),
+		notification.GetReason(),
+		convertTimeToTimeAgo(notification.GetUpdatedAt().Time),
+	))
+
+	details := getSubjectDetails(notification, func(*SubjectDetails) {
+		runOnUI(func() {
+			refreshNotificationDetail(notification.GetID())
+		})
+	})
+
+	markdown := "*" + tr("Loading...") + "*"
+
+	if err := subjectDetailsError(notification); err != nil {
+		markdown = "*" + tr("Details could not be loaded") + "*"
+	}
+
+	if details != nil {
+		info.SetText(detailInfoText(details))
+		markdown = details.Body
+	}
+
+	detailView.Lock()
+	changed := detailView.bodyMarkdown != markdown
+	detailView.bodyMarkdown = markdown
+	detailView.Unlock()
+
+	if changed {
+		body.ParseMarkdown(markdown)
+	}
+}
+
+func refreshNotificationDetail(id string) {
+	if currentDetailNotificationID() != id {
+		return
+	}
+
+	if notification := notificationStore.Find(id); notification != nil {
+		updateNotificationDetail(notification)
+	}
+}
+
+func hideNotificationDetail() {
+	setDetailNotificationID("")
+
+	detailView.Lock()
+	detailView.notification = nil
+	detailView.Unlock()
+
+	detailPane.Hide()
+	detailPane.Objects = nil
+	notificationSplit.SetOffset(1)
+
+	// back to the list's width, unless it was resized since
+	if from, to := detailWidening(); from != 0 {
+		setDetailWidening(0, 0)
+
+		if size := window.Canvas().Size(); isWidenedWidth(size.Width, to) {
+			window.Resize(fyne.NewSize(from, size.Height))
+		}
+	}
+}
+
+func detailInfoText(details *SubjectDetails) string {
+	var lines []string
+
+	if details.State != "" {
+		lines = append(lines, tr("State: %s", details.StateText()))
+	}
+
+	if details.Author != "" {
+		lines = append(lines, tr("Author: %s", "@"+details.Author))
+	}
+
+	if len(details.Labels) != 0 {
+		lines = append(lines, tr("Labels: %s", strings.Join(details.Labels, ", ")))
+	}
+
+	if len(details.Assignees) != 0 {
+		lines = append(lines, tr("Assignees: %s", "@"+strings.Join(details.Assignees, ", @")))
+	}
+
+	if len(details.Reviewers) != 0 {
+		lines = append(lines, tr("Reviewers: %s", "@"+strings.Join(details.Reviewers, ", @")))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// subjectNumber returns the issue or pull request number at the end of the
+// subject API URL.
+func subjectNumber(notification *github.Notification) (int, bool) {
+	url := notification.GetSubject().GetURL()
+
+	number, err := strconv.Atoi(url[strings.LastIndex(url, "/")+1:])
+
+	return number, err == nil
+}
+
+func fetchRecentCommentsMarkdown(ctx context.Context, notification *github.Notification) string {
+	subjectType := notification.GetSubject().GetType()
+
+	if subjectType != "Issue" && subjectType != "PullRequest" {
+		return ""
+	}
+
+	number, ok := subjectNumber(notification)
+
+	if !ok {
+		return ""
+	}
+
+	ctxTimeOut, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	sort := "created"
+	direction := "desc"
+
+	comments, _, err := githubClient().Issues.ListComments(ctxTimeOut,
+		notification.GetRepository().GetOwner().GetLogin(),
+		notification.GetRepository().GetName(),
+		number,
+		&github.IssueListCommentsOptions{
+			Sort:        &sort,
+			Direction:   &direction,
+			ListOptions: github.ListOptions{PerPage: DETAIL_COMMENTS},
+		},
+	)
+
+	if err != nil {
+		slog.Warn("Failed to fetch comments", "id", notification.GetID(), "err", err)
+		return "*" + tr("Failed to load comments") + "*"
+	}
+
+	if len(comments) == 0 {
+		return "*" + tr("No comments") + "*"
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString("## " + tr("Recent comments") + "\n\n")
+
+	for i := len(comments) - 1; i >= 0; i-- {
+		comment := comments[i]
+
+		fmt.Fprintf(&sb, "**@%s** · %s\n\n%s\n\n---\n\n",
+			comment.GetUser().GetLogin(),
+			convertTimeToTimeAgo(comment.GetCreatedAt().Time),
+			comment.GetBody(),
+		)
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRefreshNotificationDetailUpdatesInPlace(t *testing.T) {
+	fake := useFakeGitHub(t, 3)
+
+	startNotifyLoop()
+
+	eventually(t, 10*time.Second, func() bool {
+		return notificationStore.Len() == 3
+	})
+
+	notification := notificationStore.At(0)
+	comments := "GET /repos/octo/hello/issues/" + notification.GetID() + "/comments"
+
+	runOnUI(func() {
+		showNotificationDetail(notification)
+	})
+	waitUI(t)
+
+	eventually(t, 10*time.Second, func() bool {
+		return fake.requestCount(comments) == 1
+	})
+
+	detailView.Lock()
+	body := detailView.body
+	detailView.Unlock()
+
+	updated := *notification
+
+	runOnUI(func() {
+		notificationStore.Upsert(&updated)
+		refreshNotificationDetail(notification.GetID())
+		showNotificationDetail(&updated)
+	})
+	waitUI(t)
+
+	detailView.Lock()
+	rebuilt := detailView.body != body
+	shown := detailView.notification
+	detailView.Unlock()
+
+	if rebuilt {
+		t.Error("detail pane was rebuilt for the same notification")
+	}
+
+	if shown != &updated {
+		t.Error("detail pane does not show the latest update")
+	}
+
+	if count := fake.requestCount(comments); count != 1 {
+		t.Errorf("comments fetched %d times", count)
+	}
+
+	runOnUI(hideNotificationDetail)
+	waitUI(t)
+}
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"github.com/google/go-github/v55/github"
+	"gopkg.in/yaml.v3"
+)
+
+// pollDiagnostics is what the last poll returned, for the diagnostics panel.
+var pollDiagnostics = struct {
+	sync.Mutex
+	lastPoll time.Time
+	duration time.Duration
+	count    int
+	err      error
+	rate     github.Rate
+	nextPoll time.Time
+}{}
+
+func recordPoll(started time.Time, count int, resp *github.Response, err error) {
+	pollDiagnostics.Lock()
+	defer pollDiagnostics.Unlock()
+
+	pollDiagnostics.lastPoll = started
+	pollDiagnostics.duration = time.Since(started)
+	pollDiagnostics.count = count
+	pollDiagnostics.err = err
+
+	if resp != nil && resp.Rate.Limit != 0 {
+		pollDiagnostics.rate = resp.Rate
+	}
+}
+
+func recordNextPoll(next time.Time) {
+	pollDiagnostics.Lock()
+	defer pollDiagnostics.Unlock()
+
+	pollDiagnostics.nextPoll = next
+}
+
+func pollDiagnosticsText() string {
+	pollDiagnostics.Lock()
+	defer pollDiagnostics.Unlock()
+
+	if pollDiagnostics.lastPoll.IsZero() {
+		return tr("No poll yet")
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString(tr("Last poll: %s, took %s", pollDiagnostics.lastPoll.Format(time.DateTime), pollDiagnostics.duration.Round(time.Millisecond)) + "\n")
+
+	if pollDiagnostics.err != nil {
+		sb.WriteString(tr("Result: failed: %s", pollDiagnostics.err) + "\n")
+	} else {
+		sb.WriteString(trn("Result: %d notification", "Result: %d notifications", pollDiagnostics.count) + "\n")
+	}
+
+	if rate := pollDiagnostics.rate; rate.Limit != 0 {
+		sb.WriteString(tr("Rate limit: %d of %d left, resets %s", rate.Remaining, rate.Limit, rate.Reset.Format(time.TimeOnly)) + "\n")
+	}
+
+	if !pollDiagnostics.nextPoll.IsZero() {
+		sb.WriteString(tr("Next poll: %s", pollDiagnostics.nextPoll.Format(time.TimeOnly)) + "\n")
+	}
+
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// diagnosticsText is the state shown in the panel, in the app's language.
+func diagnosticsText() string {
+	language, region := currentLocale()
+
+	pollState.mutex.Lock()
+	focused := pollState.windowFocused
+	serverPollInterval := pollState.serverPollInterval
+	pollState.mutex.Unlock()
+
+	return strings.Join([]string{
+		tr("%s on %s/%s, %s", APP_ID, runtime.GOOS, runtime.GOARCH, runtime.Version()),
+		tr("Locale: %s %s, log level: %s", language.Name, region, logLevel.Level()),
+		tr("Config: %s", configPath()),
+		tr("Logs: %s", logDir()),
+		"",
+		tr("Polling"),
+		pollDiagnosticsText(),
+		tr("Interval: %s, adaptive: %s, focused: %s, server minimum: %s",
+			pollInterval(), yesNo(isAdaptivePolling()), yesNo(focused), serverPollInterval),
+		"",
+		tr("Background Tasks"),
+		strings.TrimSuffix(taskStatusText(), "\n"),
+		"",
+		tr("Outbox"),
+		outboxText(),
+		"",
+		tr("Notifications: %d listed, %d in total", notificationStore.Len(), len(notificationStore.Fetched())),
+	}, "\n")
+}
+
+func yesNo(value bool) string {
+	if value {
+		return tr("yes")
+	}
+
+	return tr("no")
+}
+
+// diagnosticsBundle adds the config without tokens, hook URLs and commands,
+// the hook log and the recent log lines to diagnosticsText, to be pasted
+// into an issue or chat.
+func diagnosticsBundle() string {
+	config := fileConfig()
+	config.Accounts = append([]AccountConfig(nil), config.Accounts...)
+	config.Hooks = append([]NotificationHook(nil), config.Hooks...)
+
+	for i := range config.Accounts {
+		if config.Accounts[i].Token != "" {
+			config.Accounts[i].Token = REDACTED
+		}
+	}
+
+	for i := range config.Hooks {
+		if config.Hooks[i].URL != "" {
+			config.Hooks[i].URL = redactURL(config.Hooks[i].URL)
+		}
+
+		if config.Hooks[i].Command != "" {
+			config.Hooks[i].Command = REDACTED
+		}
+	}
+
+	configYAML, err := yaml.Marshal(config)
+
+	if err != nil {
+		configYAML = []byte(err.Error())
+	}
+
+	return redactText(strings.Join([]string{
+		"# Diagnostics " + time.Now().Format(time.RFC3339),
+		diagnosticsText(),
+		"",
+		"# Config",
+		strings.TrimSuffix(string(configYAML), "\n"),
+		"",
+		"# Hook log",
+		hookLogText(),
+		"",
+		"# Recent logs",
+		recentLogText(),
+	}, "\n"))
+}
+
+func openDiagnosticsPanel() {
+	text := widget.NewLabel(diagnosticsText() + "\n\n" + recentLogText())
+	text.TextStyle.Monospace = true
+	text.Wrapping = fyne.TextWrapBreak
+
+	scroll := container.NewVScroll(text)
+	scroll.SetMinSize(fyne.NewSize(560, 400))
+
+	refreshButton := widget.NewButton(tr("Refresh"), func() {
+		text.SetText(diagnosticsText() + "\n\n" + recentLogText())
+		scroll.ScrollToBottom()
+	})
+
+	var copyButton *widget.Button
+	copyButton = widget.NewButton(tr("Copy diagnostics bundle"), func() {
+		window.Clipboard().SetContent(diagnosticsBundle())
+		copyButton.SetText(tr("Copied"))
+	})
+
+	dialog.ShowCustom(tr("Diagnostics"), tr("Close"), container.NewBorder(
+		nil,
+		container.NewHBox(copyButton, refreshButton),
+		nil,
+		nil,
+		scroll,
+	), window)
+	scroll.ScrollToBottom()
+	showWindow()
+}
@@ -0,0 +1,465 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v55/github"
+)
+
+const HOOK_DEFAULT_TIMEOUT time.Duration = time.Second * 10
+const HOOK_RETRY_DELAY time.Duration = time.Second * 2
+const HOOK_LOG_SIZE int = 100
+const HOOK_WORKERS int = 4
+const HOOK_QUEUE_SIZE int = 256
+
+// NotificationHook is run for every new or updated notification matching
+// its filter. A hook can POST to URL, run Command, or both.
+type NotificationHook struct {
+	Name         string   `json:"name" yaml:"name"`
+	Reasons      []string `json:"reasons,omitempty" yaml:"reasons,omitempty"`
+	Repositories []string `json:"repositories,omitempty" yaml:"repositories,omitempty"`
+	Types        []string `json:"types,omitempty" yaml:"types,omitempty"`
+	URL          string   `json:"url,omitempty" yaml:"url,omitempty"`
+	Command      string   `json:"command,omitempty" yaml:"command,omitempty"`
+	Timeout      int      `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	Retries      int      `json:"retries,omitempty" yaml:"retries,omitempty"`
+}
+
+type HookPayload struct {
+	ID            string    `json:"id"`
+	Reason        string    `json:"reason"`
+	Repository    string    `json:"repository"`
+	RepositoryURL string    `json:"repository_url"`
+	Title         string    `json:"title"`
+	Type          string    `json:"type"`
+	URL           string    `json:"url"`
+	Unread        bool      `json:"unread"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+type HookRun struct {
+	Hook           string
+	NotificationID string
+	Time           time.Time
+	Attempts       int
+	Err            error
+}
+
+type hookJob struct {
+	ctx     context.Context
+	hook    NotificationHook
+	payload HookPayload
+}
+
+var hookLog []HookRun
+var hookLogMutex sync.Mutex
+
+// hookQueue feeds a fixed number of workers, so a burst of notifications
+// does not start a goroutine and a request or process for each.
+var hookQueue = struct {
+	sync.Once
+	jobs chan hookJob
+}{}
+
+// hookFired is, by hook name, the updated_at of every notification the hook
+// already ran for. It is kept across restarts, so the first poll does not
+// fire the hooks again for every unread notification.
+var hookFired = struct {
+	sync.Mutex
+	updatedAt map[string]map[string]time.Time
+}{
+	updatedAt: make(map[string]map[string]time.Time),
+}
+
+func loadHooks() []NotificationHook {
+	return currentConfig().Hooks
+}
+
+func parseHooks(hooksJSON string) ([]NotificationHook, error) {
+	var hooks []NotificationHook
+
+	if strings.TrimSpace(hooksJSON) == "" {
+		return nil, nil
+	}
+
+	if err := json.Unmarshal([]byte(hooksJSON), &hooks); err != nil {
+		return nil, err
+	}
+
+	if err := validateHooks(hooks); err != nil {
+		return nil, err
+	}
+
+	return hooks, nil
+}
+
+func validateHooks(hooks []NotificationHook) error {
+	names := make(map[string]bool, len(hooks))
+
+	for i, hook := range hooks {
+		if hook.Name == "" {
+			return errors.New(tr("hook %d has no name", i+1))
+		}
+
+		if names[hook.Name] {
+			return errors.New(tr("hook %d (%s) has the same name as another hook", i+1, hook.Name))
+		}
+
+		names[hook.Name] = true
+
+		if hook.URL == "" && hook.Command == "" {
+			return errors.New(tr("hook %d (%s) has neither url nor command", i+1, hook.Name))
+		}
+
+		if hook.Retries < 0 {
+			return errors.New(tr("hook %d (%s) has negative retries %d", i+1, hook.Name, hook.Retries))
+		}
+	}
+
+	return nil
+}
+
+func (h *NotificationHook) matches(notification *github.Notification) bool {
+	return matchesAny(h.Reasons, notification.GetReason()) &&
+		matchesAny(h.Repositories, notification.GetRepository().GetFullName()) &&
+		matchesAny(h.Types, notification.GetSubject().GetType())
+}
+
+func matchesAny(filter []string, value string) bool {
+	if len(filter) == 0 {
+		return true
+	}
+
+	for _, f := range filter {
+		if strings.EqualFold(f, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (h *NotificationHook) timeout() time.Duration {
+	if h.Timeout <= 0 {
+		return HOOK_DEFAULT_TIMEOUT
+	}
+
+	return time.Duration(h.Timeout) * time.Second
+}
+
+func newHookPayload(notification *github.Notification) HookPayload {
+	return HookPayload{
+		ID:            notification.GetID(),
+		Reason:        notification.GetReason(),
+		Repository:    notification.GetRepository().GetFullName(),
+		RepositoryURL: notification.GetRepository().GetHTMLURL(),
+		Title:         notification.GetSubject().GetTitle(),
+		Type:          notification.GetSubject().GetType(),
+		URL:           notificationHTMLURL(notification),
+		Unread:        notification.GetUnread(),
+		UpdatedAt:     notification.GetUpdatedAt().Time,
+	}
+}
+
+func loadHookFired() {
+	firedJSON := notifierApp.Preferences().String("hook_fired")
+
+	if firedJSON == "" {
+		return
+	}
+
+	var updatedAt map[string]map[string]time.Time
+
+	if err := json.Unmarshal([]byte(firedJSON), &updatedAt); err != nil {
+		slog.Warn("Invalid hook state", "err", err)
+		return
+	}
+
+	hookFired.Lock()
+	hookFired.updatedAt = updatedAt
+	hookFired.Unlock()
+}
+
+// saveHookFired must be called with hookFired locked.
+func saveHookFired() {
+	firedJSON, err := json.Marshal(hookFired.updatedAt)
+
+	if err != nil {
+		slog.Error("Hook state not saved", "err", err)
+		return
+	}
+
+	notifierApp.Preferences().SetString("hook_fired", string(firedJSON))
+}
+
+// runHooks queues a run of every hook for each matching notification it has
+// not run for since the notification's last update. An update only counts
+// as fired for a hook once its run is queued, so a run dropped because the
+// queue is full is tried on the next poll, and a new hook fires for the
+// notifications already there.
+func runHooks(ctx context.Context, notifications []*github.Notification) {
+	hooks := loadHooks()
+
+	hookFired.Lock()
+	defer hookFired.Unlock()
+
+	changed := pruneHookFired(hooks)
+
+	for _, hook := range hooks {
+		fired := hookFired.updatedAt[hook.Name]
+
+		for _, notification := range notifications {
+			if !hook.matches(notification) {
+				continue
+			}
+
+			updatedAt := notification.GetUpdatedAt().Time
+
+			if firedAt, ok := fired[notification.GetID()]; ok && !updatedAt.After(firedAt) {
+				continue
+			}
+
+			if !queueHookRun(ctx, hook, newHookPayload(notification)) {
+				continue
+			}
+
+			if fired == nil {
+				fired = make(map[string]time.Time)
+				hookFired.updatedAt[hook.Name] = fired
+			}
+
+			fired[notification.GetID()] = updatedAt
+			changed = true
+		}
+	}
+
+	if changed {
+		saveHookFired()
+	}
+}
+
+// pruneHookFired forgets removed hooks and notifications older than the
+// lookback, as GitHub no longer returns them. It must be called with
+// hookFired locked and reports whether anything was forgotten.
+func pruneHookFired(hooks []NotificationHook) bool {
+	if hookFired.updatedAt == nil {
+		hookFired.updatedAt = make(map[string]map[string]time.Time)
+	}
+
+	names := make(map[string]bool, len(hooks))
+
+	for _, hook := range hooks {
+		names[hook.Name] = true
+	}
+
+	cutoff := time.Now().AddDate(0, 0, -lookbackDays()-1)
+	changed := false
+
+	for name, fired := range hookFired.updatedAt {
+		if !names[name] {
+			delete(hookFired.updatedAt, name)
+			changed = true
+			continue
+		}
+
+		for id, updatedAt := range fired {
+			if updatedAt.Before(cutoff) {
+				delete(fired, id)
+				changed = true
+			}
+		}
+	}
+
+	return changed
+}
+
+// queueHookRun hands the run to the hook workers. When they are that far
+// behind the run is dropped and logged, rather than blocking the caller, and
+// false is returned.
+func queueHookRun(ctx context.Context, hook NotificationHook, payload HookPayload) bool {
+	hookQueue.Do(func() {
+		hookQueue.jobs = make(chan hookJob, HOOK_QUEUE_SIZE)
+
+		for i := 0; i < HOOK_WORKERS; i++ {
+			go hookWorker()
+		}
+	})
+
+	select {
+	case hookQueue.jobs <- hookJob{ctx: ctx, hook: hook, payload: payload}:
+		return true
+	default:
+		slog.Warn("Hook queue full", "hook", hook.Name, "id", payload.ID)
+		addHookRun(HookRun{
+			Hook:           hook.Name,
+			NotificationID: payload.ID,
+			Time:           time.Now(),
+			Err:            errors.New(tr("skipped, too many hooks queued, tried again on the next poll")),
+		})
+
+		return false
+	}
+}
+
+func hookWorker() {
+	for job := range hookQueue.jobs {
+		runHook(job.ctx, job.hook, job.payload)
+	}
+}
+
+func runHook(ctx context.Context, hook NotificationHook, payload HookPayload) {
+	run := HookRun{
+		Hook:           hook.Name,
+		NotificationID: payload.ID,
+		Time:           time.Now(),
+	}
+
+	for {
+		run.Attempts++
+		run.Err = executeHook(ctx, &hook, payload)
+
+		if run.Err == nil {
+			break
+		}
+
+		slog.Warn("Hook failed", "hook", hook.Name, "id", run.NotificationID, "attempt", run.Attempts, "err", run.Err)
+
+		if run.Attempts > hook.Retries || ctx.Err() != nil {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(HOOK_RETRY_DELAY * time.Duration(run.Attempts)):
+		}
+	}
+
+	addHookRun(run)
+}
+
+func executeHook(ctx context.Context, hook *NotificationHook, payload HookPayload) error {
+	body, err := json.Marshal(payload)
+
+	if err != nil {
+		return err
+	}
+
+	ctxTimeOut, cancel := context.WithTimeout(ctx, hook.timeout())
+	defer cancel()
+
+	if hook.URL != "" {
+		if err := postHook(ctxTimeOut, hook.URL, body); err != nil {
+			return err
+		}
+	}
+
+	if hook.Command != "" {
+		if err := execHook(ctxTimeOut, hook.Command, body, payload); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func postHook(ctx context.Context, url string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return errors.New(tr("%s returned %s", url, resp.Status))
+	}
+
+	return nil
+}
+
+func execHook(ctx context.Context, command string, body []byte, payload HookPayload) error {
+	var cmd *exec.Cmd
+
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/c", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+
+	cmd.Stdin = bytes.NewReader(body)
+	cmd.Env = append(os.Environ(),
+		"GITHUB_NOTIFICATION_ID="+payload.ID,
+		"GITHUB_NOTIFICATION_REASON="+payload.Reason,
+		"GITHUB_NOTIFICATION_REPOSITORY="+payload.Repository,
+		"GITHUB_NOTIFICATION_REPOSITORY_URL="+payload.RepositoryURL,
+		"GITHUB_NOTIFICATION_TITLE="+payload.Title,
+		"GITHUB_NOTIFICATION_TYPE="+payload.Type,
+		"GITHUB_NOTIFICATION_URL="+payload.URL,
+		"GITHUB_NOTIFICATION_UPDATED_AT="+payload.UpdatedAt.Format(time.RFC3339),
+	)
+
+	output, err := cmd.CombinedOutput()
+
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(output)))
+	}
+
+	return nil
+}
+
+func addHookRun(run HookRun) {
+	hookLogMutex.Lock()
+	defer hookLogMutex.Unlock()
+
+	hookLog = append(hookLog, run)
+
+	if len(hookLog) > HOOK_LOG_SIZE {
+		hookLog = hookLog[len(hookLog)-HOOK_LOG_SIZE:]
+	}
+}
+
+func hookLogText() string {
+	hookLogMutex.Lock()
+	defer hookLogMutex.Unlock()
+
+	if len(hookLog) == 0 {
+		return tr("No hooks have run yet")
+	}
+
+	var sb strings.Builder
+
+	for i := len(hookLog) - 1; i >= 0; i-- {
+		run := hookLog[i]
+		status := tr("ok")
+
+		if run.Err != nil {
+			status = run.Err.Error()
+		}
+
+		fmt.Fprintf(&sb, "%s  %s  #%s  %s  %s\n",
+			formatDateTime(run.Time), run.Hook, run.NotificationID, trn("%d attempt", "%d attempts", run.Attempts), status)
+	}
+
+	return sb.String()
+}
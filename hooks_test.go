@@ -0,0 +1,208 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v55/github"
+)
+
+func lastHookRun(t *testing.T) HookRun {
+	t.Helper()
+
+	hookLogMutex.Lock()
+	defer hookLogMutex.Unlock()
+
+	if len(hookLog) == 0 {
+		t.Fatal("no hook run logged")
+	}
+
+	return hookLog[len(hookLog)-1]
+}
+
+func TestRunHookCountsAttempts(t *testing.T) {
+	var requests atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	for _, retries := range []int{0, 1} {
+		requests.Store(0)
+		started := time.Now()
+
+		runHook(context.Background(), NotificationHook{Name: "failing", URL: server.URL, Retries: retries}, HookPayload{ID: "1"})
+
+		run := lastHookRun(t)
+
+		if run.Attempts != retries+1 || int(requests.Load()) != retries+1 {
+			t.Errorf("retries %d: logged %d attempts, made %d", retries, run.Attempts, requests.Load())
+		}
+
+		if run.Err == nil {
+			t.Errorf("retries %d: failure not logged", retries)
+		}
+
+		// only the waits between attempts, none after the last one
+		if elapsed := time.Since(started); elapsed >= HOOK_RETRY_DELAY*time.Duration(retries+1) {
+			t.Errorf("retries %d: took %s", retries, elapsed)
+		}
+	}
+}
+
+func TestValidateHooksRejectsNegativeRetries(t *testing.T) {
+	err := validateHooks([]NotificationHook{{Name: "negative", Command: "true", Retries: -1}})
+
+	if err == nil {
+		t.Error("negative retries accepted")
+	}
+}
+
+func useTestHooks(t *testing.T, hooks ...NotificationHook) {
+	appConfig.Lock()
+	appConfig.effective.Hooks = hooks
+	appConfig.Unlock()
+}
+
+func TestRunHooksFiresOncePerHook(t *testing.T) {
+	useFakeGitHub(t, 0)
+
+	var requests sync.Map
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count, _ := requests.LoadOrStore(r.URL.Path, new(atomic.Int32))
+		count.(*atomic.Int32).Add(1)
+	}))
+	defer server.Close()
+
+	requestCount := func(path string) int32 {
+		count, ok := requests.Load(path)
+
+		if !ok {
+			return 0
+		}
+
+		return count.(*atomic.Int32).Load()
+	}
+
+	hookFired.Lock()
+	hookFired.updatedAt = nil
+	hookFired.Unlock()
+
+	id := "42"
+	updatedAt := github.Timestamp{Time: time.Now().Truncate(time.Second)}
+	notification := &github.Notification{ID: &id, UpdatedAt: &updatedAt}
+	first := NotificationHook{Name: "first", URL: server.URL + "/first"}
+
+	useTestHooks(t, first)
+	runHooks(context.Background(), []*github.Notification{notification})
+
+	eventually(t, 5*time.Second, func() bool { return requestCount("/first") == 1 })
+
+	// as after a restart
+	hookFired.Lock()
+	hookFired.updatedAt = nil
+	hookFired.Unlock()
+	loadHookFired()
+
+	runHooks(context.Background(), []*github.Notification{notification})
+
+	// a hook added later
+	useTestHooks(t, first, NotificationHook{Name: "second", URL: server.URL + "/second"})
+	runHooks(context.Background(), []*github.Notification{notification})
+
+	eventually(t, 5*time.Second, func() bool { return requestCount("/second") == 1 })
+
+	later := github.Timestamp{Time: updatedAt.Add(time.Minute)}
+	updated := &github.Notification{ID: &id, UpdatedAt: &later}
+	runHooks(context.Background(), []*github.Notification{updated})
+
+	eventually(t, 5*time.Second, func() bool { return requestCount("/first") == 2 && requestCount("/second") == 2 })
+
+	time.Sleep(50 * time.Millisecond)
+
+	if count := requestCount("/first"); count != 2 {
+		t.Errorf("first hook ran %d times for two updates", count)
+	}
+}
+
+func TestRunHooksRetriesDroppedRuns(t *testing.T) {
+	useFakeGitHub(t, 0)
+
+	hookFired.Lock()
+	hookFired.updatedAt = nil
+	hookFired.Unlock()
+
+	id := "7"
+	updatedAt := github.Timestamp{Time: time.Now().Truncate(time.Second)}
+	notification := &github.Notification{ID: &id, UpdatedAt: &updatedAt}
+	hook := NotificationHook{Name: "dropped", Command: "true"}
+
+	useTestHooks(t, hook)
+
+	// start the workers, then swap in a queue nobody reads, which is full
+	queueHookRun(context.Background(), NotificationHook{Name: "warm up", Command: "true"}, HookPayload{})
+
+	jobs := hookQueue.jobs
+	hookQueue.jobs = make(chan hookJob)
+	runHooks(context.Background(), []*github.Notification{notification})
+	hookQueue.jobs = jobs
+
+	hookFired.Lock()
+	_, fired := hookFired.updatedAt["dropped"][id]
+	hookFired.Unlock()
+
+	if fired {
+		t.Fatal("dropped run marked as fired")
+	}
+
+	runHooks(context.Background(), []*github.Notification{notification})
+
+	hookFired.Lock()
+	_, fired = hookFired.updatedAt["dropped"][id]
+	hookFired.Unlock()
+
+	if !fired {
+		t.Error("run not queued on the next poll")
+	}
+}
+
+func TestValidateHooksNames(t *testing.T) {
+	tests := map[string][]NotificationHook{
+		"no name":   {{Command: "true"}},
+		"duplicate": {{Name: "a", Command: "true"}, {Name: "a", URL: "http://localhost"}},
+	}
+
+	for name, hooks := range tests {
+		if err := validateHooks(hooks); err == nil {
+			t.Errorf("%s: accepted", name)
+		}
+	}
+
+	if err := validateHooks([]NotificationHook{{Name: "a", Command: "true"}, {Name: "b", Command: "true"}}); err != nil {
+		t.Errorf("distinct names rejected: %s", err)
+	}
+}
+
+func TestHookPayloadLinksToWebPage(t *testing.T) {
+	notification := &github.Notification{
+		Subject: &github.NotificationSubject{
+			URL: github.String("https://api.github.com/repos/octo/hello/pulls/7"),
+		},
+		Repository: &github.Repository{
+			URL:     github.String("https://api.github.com/repos/octo/hello"),
+			HTMLURL: github.String("https://github.com/octo/hello"),
+		},
+	}
+
+	if url := newHookPayload(notification).URL; url != "https://github.com/octo/hello/pull/7" {
+		t.Errorf("payload url = %q", url)
+	}
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const DEFAULT_LANGUAGE string = "en"
+
+// Language is a bundled translation. Messages are keyed by their English
+// text; plural messages hold one form per plural category, in the order
+// Plural numbers them.
+type Language struct {
+	Name string
+	// Plural picks the form for n, e.g. 0 for "1 minute", 1 for "2 minutes".
+	Plural   func(n int) int
+	Messages map[string][]string
+	// DateLayout and TimeLayout format absolute times, keyed by region with
+	// "" as the fallback. Only English layouts may spell out months, as Go
+	// writes their names in English.
+	DateLayout map[string]string
+	TimeLayout map[string]string
+}
+
+func pluralOneOther(n int) int {
+	if n == 1 {
+		return 0
+	}
+
+	return 1
+}
+
+// pluralFrench treats 0 as singular too.
+func pluralFrench(n int) int {
+	if n <= 1 {
+		return 0
+	}
+
+	return 1
+}
+
+func pluralNone(int) int {
+	return 0
+}
+
+// locale is the language and region used for everything shown in the UI.
+var locale = struct {
+	sync.RWMutex
+	language string
+	region   string
+}{
+	language: DEFAULT_LANGUAGE,
+}
+
+// parseLocale reads a POSIX or BCP 47 locale like "de_DE.UTF-8", "pt-BR" or
+// "ja" into a lower case language and an upper case region.
+func parseLocale(text string) (string, string) {
+	text, _, _ = strings.Cut(text, ".")
+	text, _, _ = strings.Cut(text, "@")
+	language, region, _ := strings.Cut(strings.ReplaceAll(text, "-", "_"), "_")
+
+	return strings.ToLower(language), strings.ToUpper(region)
+}
+
+// environmentLocale follows gettext: LANGUAGE may list several languages,
+// then LC_ALL, LC_MESSAGES and LANG are tried in that order. Only bundled
+// languages are picked.
+func environmentLocale() (string, string) {
+	var candidates []string
+
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if value := os.Getenv(name); value != "" {
+			candidates = append(candidates, value)
+			break
+		}
+	}
+
+	// LANGUAGE is ignored for the "C" locale
+	if len(candidates) == 0 || (candidates[0] != "C" && candidates[0] != "POSIX") {
+		languages := strings.Split(os.Getenv("LANGUAGE"), ":")
+		candidates = append(languages, candidates...)
+	}
+
+	for _, candidate := range candidates {
+		language, region := parseLocale(candidate)
+
+		if _, ok := LANGUAGES[language]; ok {
+			return language, region
+		}
+	}
+
+	return DEFAULT_LANGUAGE, ""
+}
+
+// setLocale switches the UI language, taken from the environment when
+// text is empty.
+func setLocale(text string) {
+	language, region := parseLocale(text)
+
+	if _, ok := LANGUAGES[language]; !ok {
+		language, region = environmentLocale()
+	}
+
+	locale.Lock()
+	locale.language, locale.region = language, region
+	locale.Unlock()
+}
+
+func currentLocale() (*Language, string) {
+	locale.RLock()
+	defer locale.RUnlock()
+
+	return LANGUAGES[locale.language], locale.region
+}
+
+// tr translates message, formatting args into it like fmt.Sprintf.
+// Messages without a translation are shown in English.
+func tr(message string, args ...any) string {
+	language, _ := currentLocale()
+
+	if forms, ok := language.Messages[message]; ok && len(forms) != 0 {
+		message = forms[0]
+	}
+
+	if len(args) == 0 {
+		return message
+	}
+
+	return fmt.Sprintf(message, args...)
+}
+
+// trn translates a message that depends on the number n, which is passed
+// to the format as its first argument.
+func trn(singular string, plural string, n int, args ...any) string {
+	language, _ := currentLocale()
+	args = append([]any{n}, args...)
+
+	if forms, ok := language.Messages[singular]; ok && len(forms) != 0 {
+		form := language.Plural(n)
+
+		if form >= len(forms) {
+			form = len(forms) - 1
+		}
+
+		return fmt.Sprintf(forms[form], args...)
+	}
+
+	if pluralOneOther(n) == 0 {
+		return fmt.Sprintf(singular, args...)
+	}
+
+	return fmt.Sprintf(plural, args...)
+}
+
+func layoutFor(layouts map[string]string, region string) string {
+	if layout, ok := layouts[region]; ok {
+		return layout
+	}
+
+	return layouts[""]
+}
+
+// formatDate writes t as a date in the current locale.
+func formatDate(t time.Time) string {
+	language, region := currentLocale()
+
+	return t.Local().Format(layoutFor(language.DateLayout, region))
+}
+
+// formatTime writes the time of day of t in the current locale.
+func formatTime(t time.Time) string {
+	language, region := currentLocale()
+
+	return t.Local().Format(layoutFor(language.TimeLayout, region))
+}
+
+// formatDateTime writes t with both date and time in the current locale.
+func formatDateTime(t time.Time) string {
+	return formatDate(t) + " " + formatTime(t)
+}
+
+// languageCodes lists the bundled languages for the settings window.
+func languageCodes() []string {
+	codes := make([]string, 0, len(LANGUAGES))
+
+	for code := range LANGUAGES {
+		codes = append(codes, code)
+	}
+
+	sort.Strings(codes)
+
+	return codes
+}
+
+func isBundledLanguage(text string) bool {
+	language, _ := parseLocale(text)
+	_, ok := LANGUAGES[language]
+
+	return ok
+}
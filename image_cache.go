@@ -0,0 +1,336 @@
+package main
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+)
+
+const IMAGE_CACHE_SIZE int = 200
+const IMAGE_LOADER_WORKERS int = 4
+const IMAGE_LOAD_TIMEOUT time.Duration = time.Second * 10
+const IMAGE_FAILURE_TTL time.Duration = time.Minute * 2
+const IMAGE_DISK_CACHE_SIZE int = 1000
+
+// imageCache loads remote images on a small worker pool, keeps the most
+// recently used ones in memory and persists them on disk, revalidating the
+// disk copy with its ETag the first time it is used in a session. Failed
+// loads are not retried for IMAGE_FAILURE_TTL, so a broken URL is not
+// requested again on every refresh. The disk copies are capped at
+// diskCapacity images, dropping the least recently used ones.
+type imageCache struct {
+	mutex        sync.Mutex
+	entries      map[string]*list.Element
+	lru          *list.List
+	capacity     int
+	pending      map[string][]func(fyne.Resource)
+	failed       map[string]time.Time
+	jobs         chan string
+	dir          string
+	diskCapacity int
+	diskMutex    sync.Mutex
+}
+
+type imageCacheEntry struct {
+	url      string
+	resource fyne.Resource
+}
+
+func newImageCache(capacity int, workers int, dir string) *imageCache {
+	cache := &imageCache{
+		entries:      make(map[string]*list.Element),
+		lru:          list.New(),
+		capacity:     capacity,
+		pending:      make(map[string][]func(fyne.Resource)),
+		failed:       make(map[string]time.Time),
+		jobs:         make(chan string, capacity),
+		dir:          dir,
+		diskCapacity: IMAGE_DISK_CACHE_SIZE,
+	}
+
+	if dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			slog.Warn("Image cache dir", "err", err)
+			cache.dir = ""
+		}
+	}
+
+	for i := 0; i < workers; i++ {
+		go cache.worker()
+	}
+
+	return cache
+}
+
+// Get returns the cached image for url, or placeholder while the image is
+// loaded in the background. onLoad is called once the image is available.
+func (c *imageCache) Get(url string, placeholder fyne.Resource, onLoad func(fyne.Resource)) fyne.Resource {
+	if url == "" {
+		return placeholder
+	}
+
+	c.mutex.Lock()
+
+	if element, ok := c.entries[url]; ok {
+		c.lru.MoveToFront(element)
+		resource := element.Value.(*imageCacheEntry).resource
+		c.mutex.Unlock()
+		return resource
+	}
+
+	if retryAt, ok := c.failed[url]; ok {
+		if time.Now().Before(retryAt) {
+			c.mutex.Unlock()
+			return placeholder
+		}
+
+		delete(c.failed, url)
+	}
+
+	waiters, loading := c.pending[url]
+	c.pending[url] = append(waiters, onLoad)
+	c.mutex.Unlock()
+
+	if !loading {
+		select {
+		case c.jobs <- url:
+		default:
+			// queue is full, drop the request so it is retried on next refresh
+			c.mutex.Lock()
+			delete(c.pending, url)
+			c.mutex.Unlock()
+		}
+	}
+
+	return placeholder
+}
+
+func (c *imageCache) worker() {
+	for url := range c.jobs {
+		resource, err := c.load(url)
+
+		c.mutex.Lock()
+		waiters := c.pending[url]
+		delete(c.pending, url)
+
+		if err == nil {
+			c.put(url, resource)
+		} else {
+			c.putFailed(url)
+		}
+		c.mutex.Unlock()
+
+		if err != nil {
+			slog.Debug("Failed to load image", "url", url, "err", err)
+			continue
+		}
+
+		for _, onLoad := range waiters {
+			if onLoad != nil {
+				onLoad(resource)
+			}
+		}
+	}
+}
+
+// put must be called with the mutex held.
+func (c *imageCache) put(url string, resource fyne.Resource) {
+	if element, ok := c.entries[url]; ok {
+		element.Value.(*imageCacheEntry).resource = resource
+		c.lru.MoveToFront(element)
+		return
+	}
+
+	c.entries[url] = c.lru.PushFront(&imageCacheEntry{url: url, resource: resource})
+
+	for c.lru.Len() > c.capacity {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*imageCacheEntry).url)
+	}
+}
+
+// putFailed must be called with the mutex held.
+func (c *imageCache) putFailed(url string) {
+	now := time.Now()
+
+	if len(c.failed) >= c.capacity {
+		for failedURL, retryAt := range c.failed {
+			if now.After(retryAt) {
+				delete(c.failed, failedURL)
+			}
+		}
+	}
+
+	c.failed[url] = now.Add(IMAGE_FAILURE_TTL)
+}
+
+func (c *imageCache) load(url string) (fyne.Resource, error) {
+	content, etag := c.readDisk(url)
+
+	ctx, cancel := context.WithTimeout(context.Background(), IMAGE_LOAD_TIMEOUT)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if content != nil && etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+
+	if err != nil {
+		if content != nil {
+			return fyne.NewStaticResource(url, content), nil
+		}
+
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && content != nil {
+		return fyne.NewStaticResource(url, content), nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		if content != nil {
+			return fyne.NewStaticResource(url, content), nil
+		}
+
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	content, err = io.ReadAll(resp.Body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	c.writeDisk(url, content, resp.Header.Get("ETag"))
+
+	return fyne.NewStaticResource(url, content), nil
+}
+
+func (c *imageCache) diskPath(url string) string {
+	sum := sha1.Sum([]byte(url))
+
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:]))
+}
+
+func (c *imageCache) readDisk(url string) ([]byte, string) {
+	if c.dir == "" {
+		return nil, ""
+	}
+
+	path := c.diskPath(url)
+
+	content, err := os.ReadFile(path)
+
+	if err != nil {
+		return nil, ""
+	}
+
+	// marks it as recently used for pruneDisk
+	now := time.Now()
+	_ = os.Chtimes(path, now, now)
+
+	etag, _ := os.ReadFile(path + ".etag")
+
+	return content, string(etag)
+}
+
+func (c *imageCache) writeDisk(url string, content []byte, etag string) {
+	if c.dir == "" {
+		return
+	}
+
+	path := c.diskPath(url)
+
+	c.diskMutex.Lock()
+	defer c.diskMutex.Unlock()
+
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		slog.Warn("Failed to write image cache", "err", err)
+		return
+	}
+
+	if etag == "" {
+		_ = os.Remove(path + ".etag")
+	} else if err := os.WriteFile(path+".etag", []byte(etag), 0o644); err != nil {
+		slog.Warn("Failed to write image cache", "err", err)
+	}
+
+	c.pruneDisk()
+}
+
+// pruneDisk removes the least recently used images, with their ETags, once
+// the dir holds more than diskCapacity. It must be called with diskMutex held.
+func (c *imageCache) pruneDisk() {
+	entries, err := os.ReadDir(c.dir)
+
+	if err != nil {
+		slog.Warn("Failed to prune image cache", "err", err)
+		return
+	}
+
+	type diskImage struct {
+		path    string
+		modTime time.Time
+	}
+
+	var images []diskImage
+
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) == ".etag" {
+			continue
+		}
+
+		info, err := entry.Info()
+
+		if err != nil {
+			continue
+		}
+
+		images = append(images, diskImage{filepath.Join(c.dir, entry.Name()), info.ModTime()})
+	}
+
+	if len(images) <= c.diskCapacity {
+		return
+	}
+
+	sort.Slice(images, func(i, j int) bool {
+		return images[i].modTime.Before(images[j].modTime)
+	})
+
+	for _, image := range images[:len(images)-c.diskCapacity] {
+		_ = os.Remove(image.path)
+		_ = os.Remove(image.path + ".etag")
+	}
+}
+
+func imageCacheDir() string {
+	dir, err := os.UserCacheDir()
+
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(dir, APP_ID, "avatars")
+}
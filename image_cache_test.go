@@ -0,0 +1,93 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestImageCacheRemembersFailures(t *testing.T) {
+	var requests atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	cache := newImageCache(10, 1, "")
+	url := server.URL + "/missing.png"
+
+	cache.Get(url, nil, nil)
+
+	eventually(t, 5*time.Second, func() bool {
+		cache.mutex.Lock()
+		defer cache.mutex.Unlock()
+
+		_, failed := cache.failed[url]
+		return failed
+	})
+
+	// as on every list refresh
+	for i := 0; i < 5; i++ {
+		cache.Get(url, nil, nil)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+
+	if count := requests.Load(); count != 1 {
+		t.Errorf("broken image requested %d times", count)
+	}
+
+	cache.mutex.Lock()
+	cache.failed[url] = time.Now().Add(-time.Second)
+	cache.mutex.Unlock()
+
+	cache.Get(url, nil, nil)
+
+	eventually(t, 5*time.Second, func() bool {
+		return requests.Load() == 2
+	})
+}
+
+func TestImageCachePrunesDisk(t *testing.T) {
+	cache := newImageCache(10, 1, t.TempDir())
+	cache.diskCapacity = 2
+
+	urls := []string{"https://example.com/1.png", "https://example.com/2.png", "https://example.com/3.png"}
+
+	for i, url := range urls[:2] {
+		cache.writeDisk(url, []byte("image"), "etag")
+
+		modTime := time.Now().Add(time.Duration(i-10) * time.Minute)
+		_ = os.Chtimes(cache.diskPath(url), modTime, modTime)
+	}
+
+	// used since, so the second one is the oldest
+	if content, _ := cache.readDisk(urls[0]); content == nil {
+		t.Fatal("image not written to disk")
+	}
+
+	cache.writeDisk(urls[2], []byte("image"), "etag")
+
+	tests := []struct {
+		url  string
+		kept bool
+	}{
+		{urls[0], true},
+		{urls[1], false},
+		{urls[2], true},
+	}
+
+	for _, test := range tests {
+		for _, path := range []string{cache.diskPath(test.url), cache.diskPath(test.url) + ".etag"} {
+			if _, err := os.Stat(path); (err == nil) != test.kept {
+				t.Errorf("%s kept %v, want %v", filepath.Base(path), err == nil, test.kept)
+			}
+		}
+	}
+}
@@ -0,0 +1,273 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const LOG_FILE_NAME string = "notify.log"
+const LOG_FILE_MAX_SIZE int64 = 5 << 20
+const LOG_FILE_BACKUPS int = 3
+const LOG_RECENT_LINES int = 300
+const REDACTED string = "[REDACTED]"
+
+// LOG_SECRET_MIN_LENGTH keeps short values from redacting ordinary words.
+const LOG_SECRET_MIN_LENGTH int = 8
+
+// TOKEN_PATTERN matches classic, OAuth, app and fine-grained GitHub tokens.
+var TOKEN_PATTERN = regexp.MustCompile(`\b(gh[pousr]_[A-Za-z0-9]{20,}|github_pat_[A-Za-z0-9_]{20,})\b`)
+
+var logLevel = new(slog.LevelVar)
+
+// recentLogs keeps the last lines written for the diagnostics panel.
+var recentLogs = struct {
+	sync.Mutex
+	lines []string
+}{}
+
+// logSecrets replaces the configured tokens, which are redacted even when
+// they do not look like a GitHub token, and the hook URLs and commands, which
+// can carry credentials of their own.
+var logSecrets = struct {
+	sync.RWMutex
+	replacer *strings.Replacer
+}{}
+
+// rotatingFile appends to a log file, moving it to .1, .2, ... once it
+// grows past LOG_FILE_MAX_SIZE.
+type rotatingFile struct {
+	mutex sync.Mutex
+	path  string
+	file  *os.File
+	size  int64
+}
+
+// logDir is in the app's data directory, next to its preferences.
+func logDir() string {
+	if notifierApp == nil {
+		return filepath.Join(os.TempDir(), APP_ID, "logs")
+	}
+
+	return filepath.Join(notifierApp.Storage().RootURI().Path(), "logs")
+}
+
+// setupLogging sends log/slog and the standard logger to stderr, the log
+// file and the recent lines, at debug level when debug is set.
+func setupLogging(debug bool) {
+	if debug {
+		logLevel.Set(slog.LevelDebug)
+	}
+
+	output := &logOutput{}
+
+	file, err := openRotatingFile(filepath.Join(logDir(), LOG_FILE_NAME))
+
+	if err == nil {
+		output.file = file
+	}
+
+	slog.SetDefault(slog.New(slog.NewTextHandler(output, &slog.HandlerOptions{
+		Level:       logLevel,
+		ReplaceAttr: redactAttr,
+	})))
+
+	if err != nil {
+		slog.Warn("Log file not opened", "err", err)
+	}
+}
+
+// logOutput writes every line to all outputs, so a full disk does not
+// silence stderr or the diagnostics panel.
+type logOutput struct {
+	file *rotatingFile
+}
+
+func (o *logOutput) Write(p []byte) (int, error) {
+	_, _ = os.Stderr.Write(p)
+
+	if o.file != nil {
+		_, _ = o.file.Write(p)
+	}
+
+	recentLogs.Lock()
+	recentLogs.lines = append(recentLogs.lines, strings.TrimRight(string(p), "\n"))
+
+	if len(recentLogs.lines) > LOG_RECENT_LINES {
+		recentLogs.lines = recentLogs.lines[len(recentLogs.lines)-LOG_RECENT_LINES:]
+	}
+	recentLogs.Unlock()
+
+	return len(p), nil
+}
+
+func recentLogText() string {
+	recentLogs.Lock()
+	defer recentLogs.Unlock()
+
+	return strings.Join(recentLogs.lines, "\n")
+}
+
+func openRotatingFile(path string) (*rotatingFile, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, err
+	}
+
+	r := &rotatingFile{path: path}
+
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (r *rotatingFile) open() error {
+	file, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+
+	if err != nil {
+		return err
+	}
+
+	info, err := file.Stat()
+
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	r.file = file
+	r.size = info.Size()
+
+	return nil
+}
+
+func (r *rotatingFile) Write(p []byte) (int, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.size+int64(len(p)) > LOG_FILE_MAX_SIZE {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+
+	return n, err
+}
+
+func (r *rotatingFile) rotate() error {
+	r.file.Close()
+
+	for i := LOG_FILE_BACKUPS - 1; i > 0; i-- {
+		err := os.Rename(fmt.Sprintf("%s.%d", r.path, i), fmt.Sprintf("%s.%d", r.path, i+1))
+
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+
+	if err := os.Rename(r.path, r.path+".1"); err != nil {
+		return err
+	}
+
+	return r.open()
+}
+
+func setLogSecrets(configs ...Config) {
+	secrets := make(map[string]string)
+
+	for _, config := range configs {
+		for _, account := range config.Accounts {
+			if len(account.Token) >= LOG_SECRET_MIN_LENGTH {
+				secrets[account.Token] = REDACTED
+			}
+		}
+
+		for _, hook := range config.Hooks {
+			if len(hook.URL) >= LOG_SECRET_MIN_LENGTH {
+				secrets[hook.URL] = redactURL(hook.URL)
+			}
+
+			if len(hook.Command) >= LOG_SECRET_MIN_LENGTH {
+				secrets[hook.Command] = REDACTED
+			}
+		}
+	}
+
+	values := make([]string, 0, len(secrets))
+
+	for value := range secrets {
+		values = append(values, value)
+	}
+
+	// a URL inside a command is replaced as part of the command
+	sort.Slice(values, func(i, j int) bool {
+		return len(values[i]) > len(values[j])
+	})
+
+	var pairs []string
+
+	for _, value := range values {
+		pairs = append(pairs, value, secrets[value])
+	}
+
+	logSecrets.Lock()
+	logSecrets.replacer = strings.NewReplacer(pairs...)
+	logSecrets.Unlock()
+}
+
+// redactURL keeps only the scheme and host of a URL, as its path and query
+// often hold a key.
+func redactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return REDACTED
+	}
+
+	return u.Scheme + "://" + u.Host + "/" + REDACTED
+}
+
+// redactAttr hides tokens in any attribute, including the message.
+// Attributes named like a secret are hidden completely.
+func redactAttr(groups []string, a slog.Attr) slog.Attr {
+	key := strings.ToLower(a.Key)
+
+	if strings.Contains(key, "token") || strings.Contains(key, "authorization") {
+		return slog.String(a.Key, REDACTED)
+	}
+
+	switch a.Value.Kind() {
+	case slog.KindString:
+		return slog.String(a.Key, redactText(a.Value.String()))
+	case slog.KindAny:
+		return slog.String(a.Key, redactText(fmt.Sprint(a.Value.Any())))
+	}
+
+	return a
+}
+
+func redactText(text string) string {
+	text = TOKEN_PATTERN.ReplaceAllString(text, REDACTED)
+
+	logSecrets.RLock()
+	replacer := logSecrets.replacer
+	logSecrets.RUnlock()
+
+	if replacer != nil {
+		text = replacer.Replace(text)
+	}
+
+	return text
+}
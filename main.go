@@ -2,19 +2,23 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
 	"fmt"
-	"image/color"
-	"log"
+	"log/slog"
+	"net/url"
+	"os"
 	"os/exec"
 	"runtime"
+	"strings"
 	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/app"
-	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/dialog"
 	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
 	"github.com/electricbubble/go-toast"
 	"github.com/google/go-github/v55/github"
@@ -23,13 +27,17 @@ import (
 const APP_ID string = "org.mygithub.notification"
 const REPEAT_TIME time.Duration = time.Second * 30
 const DAY_OLDER int = 5
+const NOTIFY_TASK string = "githubNotifyLoop"
 
 var notifierApp fyne.App
 var window fyne.Window
 var globalCtx context.Context
-var ctxMap map[string]*context.CancelFunc
-var notificationList []*github.Notification
+var notificationStore *NotificationStore
 var notificationListComponent *widget.List
+var altMessageLabel *widget.Label
+var toolbar *widget.Toolbar
+var showAllAction *widget.ToolbarAction
+var searchEntry *widget.Entry
 
 type MyNotification struct {
 	Status       bool
@@ -41,238 +49,415 @@ type MyNotification struct {
 }
 
 func main() {
+	toggle := flag.Bool("toggle", false, "show or hide the window of the running app")
+	minimized := flag.Bool("minimized", false, "start hidden in the system tray")
+	debug := flag.Bool("debug", false, "log debug messages")
+	flag.Parse()
+
+	if *toggle {
+		if err := sendControlCommand("toggle"); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+
+		return
+	}
+
+	// a second start brings up the running app instead
+	command := "show"
+
+	if *minimized {
+		command = ""
+	}
+
+	if forwardToRunningInstance(command) {
+		slog.Info("Already running")
+		return
+	}
+
 	notifierApp = app.NewWithID(APP_ID)
 
+	setupLogging(*debug)
+
 	notifierApp.Settings().SetTheme(&myTheme{})
 
-	window = notifierApp.NewWindow("Github Notifications")
+	startUIEventLoop()
 
-	notificationListComponent = addNotificationListUI()
+	configErr := loadConfig()
+	setLocale(currentConfig().Appearance.Language)
+	window = newMainWindow(currentConfig().Appearance.CompactWindow)
+	applyAppearance(currentConfig().Appearance)
+	loadSnoozes()
+	loadOutbox()
+	loadHookFired()
 
-	windowContentRefresh("Loading...")
+	notificationStore = newNotificationStore()
+	notificationStore.SetFilter(notificationFilter)
+	notificationStore.AddListener(onNotificationStoreChange)
 
-	window.Resize(fyne.NewSize(400, 600))
+	notificationListComponent = addNotificationListUI()
+
+	windowContentInit()
+	windowContentRefresh(tr("Loading..."))
 
 	globalCtx = context.Background()
-	ctxMap = make(map[string]*context.CancelFunc)
+	taskSupervisor = newTaskSupervisor(globalCtx)
+	startOutbox()
 
-	github_token := notifierApp.Preferences().String("github_token")
+	if configErr != nil {
+		slog.Error("Config not loaded", "err", configErr)
+		dialog.ShowError(fmt.Errorf("%s\n%w", tr("The config file could not be used, so the last working settings are in effect until it is fixed:"), configErr), window)
+	}
 
-	if github_token == "" {
+	if githubToken() == "" {
+		windowContentRefresh(tr("Add a GitHub token in Settings"))
 		openSettingsPanel()
 	} else {
-		startAsyncProcess("githubNotifyLoop", githubNotifyLoop)
+		startNotifyLoop()
 	}
 
+	if startup := currentConfig().Startup; startup.Autostart {
+		go func() {
+			if err := setAutostart(startup); err != nil {
+				slog.Warn("Autostart not updated", "err", err)
+			}
+		}()
+	}
+
+	watchConfig()
 	addSystemStrayMenu()
+	addKeyboardShortcuts()
+	listenControlSocket()
+	watchWindowFocus()
 	window.SetCloseIntercept(func() {
-		window.Hide()
+		hideWindow()
 	})
 
+	if *minimized || currentConfig().Startup.Minimized {
+		notifierApp.Run()
+		return
+	}
+
+	setWindowVisible(true)
 	window.ShowAndRun()
 }
 
-func fetchNotifications(ctx context.Context) ([]*github.Notification, error) {
-	github_token := notifierApp.Preferences().String("github_token")
+// githubAPIURL points the client at another API, like a fake one in tests.
+var githubAPIURL string
+
+func githubClient() *github.Client {
+	return newGitHubClient(githubToken())
+}
 
-	client := github.NewClient(nil).WithAuthToken(github_token)
+func newGitHubClient(token string) *github.Client {
+	client := github.NewClient(nil).WithAuthToken(token)
+
+	if githubAPIURL != "" {
+		if baseURL, err := url.Parse(githubAPIURL); err == nil {
+			client.BaseURL = baseURL
+		}
+	}
+
+	return client
+}
+
+func fetchNotifications(ctx context.Context) ([]*github.Notification, error) {
+	client := githubClient()
 
 	opt := &github.NotificationListOptions{
-		Since: time.Now().AddDate(0, 0, -DAY_OLDER),
+		All:   isShowAllNotifications(),
+		Since: time.Now().AddDate(0, 0, -lookbackDays()),
 	}
 
 	ctxTimeOut, cancel := context.WithTimeout(ctx, time.Second*10)
 	defer cancel()
 
-	notifications, _, err := client.Activity.ListNotifications(ctxTimeOut, opt)
+	started := time.Now()
+	notifications, resp, err := client.Activity.ListNotifications(ctxTimeOut, opt)
+	recordPoll(started, len(notifications), resp, err)
+
+	if resp != nil {
+		recordServerPollInterval(resp.Header)
+		recordTokenExpiration(resp.Header)
+	}
 
 	if err != nil {
-		fmt.Println(err)
+		slog.Warn("Failed to fetch notifications", "err", err)
 		return nil, err
 	}
 
+	slog.Debug("Fetched notifications", "count", len(notifications), "rate_remaining", resp.Rate.Remaining)
+
 	return notifications, nil
 }
 
-func markAsReadNotification(notification *github.Notification) (bool, error) {
-	github_token := notifierApp.Preferences().String("github_token")
-
-	client := github.NewClient(nil).WithAuthToken(github_token)
+func markAsReadNotification(ctx context.Context, notification *github.Notification) (bool, error) {
+	client := githubClient()
 
-	ctxTimeOut, cancel := context.WithTimeout(globalCtx, time.Second*10)
+	ctxTimeOut, cancel := context.WithTimeout(ctx, time.Second*10)
 	defer cancel()
 
 	_, err := client.Activity.MarkThreadRead(ctxTimeOut, notification.GetID())
 
 	if err != nil {
-		fmt.Println(err)
+		slog.Warn("Failed to mark as read", "id", notification.GetID(), "err", err)
 		return false, err
 	}
 
-	log.Println("Mark as read success")
+	slog.Debug("Marked as read", "id", notification.GetID())
 
 	return true, nil
 }
 
-func addNotifications(notifications []*github.Notification, err error) {
-	log.Println("Add notifications")
+func markAsDoneNotification(ctx context.Context, notification *github.Notification) (bool, error) {
+	client := githubClient()
+
+	ctxTimeOut, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	req, err := client.NewRequest("DELETE", "notifications/threads/"+notification.GetID(), nil)
+
 	if err != nil {
-		notificationList = nil
-		log.Println(err)
-		windowContentRefresh("Failed to fetch notifications")
-		return
+		return false, err
 	}
 
-	notificationsDiff := getNotificationListDiff(notifications)
+	_, err = client.Do(ctxTimeOut, req, nil)
 
-	notificationList = notifications
+	if err != nil {
+		slog.Warn("Failed to mark as done", "id", notification.GetID(), "err", err)
+		return false, err
+	}
 
-	windowContentRefresh("No New Notifications")
+	slog.Debug("Marked as done", "id", notification.GetID())
 
-	if len(notificationsDiff) != 0 {
-		_ = toast.Push("Github Notifications",
-			toast.WithTitle(fmt.Sprintf("You have %d new notifications", len(notificationsDiff))),
-			toast.WithObjectiveC(true),
-		)
-	}
+	return true, nil
 }
 
-func getNotificationListDiff(notifications []*github.Notification) []*github.Notification {
-	var diff []*github.Notification
+func unsubscribeNotification(ctx context.Context, notification *github.Notification) (bool, error) {
+	client := githubClient()
 
-	for _, notification := range notifications {
-		if !isNotificationExist(notification) {
-			diff = append(diff, notification)
-		}
+	ctxTimeOut, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	_, err := client.Activity.DeleteThreadSubscription(ctxTimeOut, notification.GetID())
+
+	if err != nil {
+		slog.Warn("Failed to unsubscribe", "id", notification.GetID(), "err", err)
+		return false, err
 	}
 
-	return diff
+	slog.Debug("Unsubscribed", "id", notification.GetID())
+
+	return true, nil
 }
 
-func isNotificationExist(notification *github.Notification) bool {
-	for _, n := range notificationList {
-		if n.GetID() == notification.GetID() {
-			return true
-		}
+// notificationHTMLURL links to the issue, pull request or release when its
+// details are known, and to the repository otherwise.
+func notificationHTMLURL(notification *github.Notification) string {
+	details := getSubjectDetails(notification, nil)
+
+	if details != nil && details.HTMLURL != "" {
+		return details.HTMLURL
 	}
 
-	return false
-}
+	if url := subjectHTMLURL(notification); url != "" {
+		return url
+	}
 
-func waitForProcess(ch *chan int) {
-	<-*ch
+	return notification.GetRepository().GetHTMLURL()
 }
 
-func processEnd(ch *chan int) {
-	*ch <- 1
+// subjectHTMLURL derives the web page of an issue, pull request or commit
+// from its API URL, for when the details are not loaded yet.
+func subjectHTMLURL(notification *github.Notification) string {
+	apiURL := notification.GetSubject().GetURL()
+	repoAPIURL := notification.GetRepository().GetURL()
+	repoHTMLURL := notification.GetRepository().GetHTMLURL()
+
+	if repoAPIURL == "" || repoHTMLURL == "" || !strings.HasPrefix(apiURL, repoAPIURL+"/") {
+		return ""
+	}
+
+	path := strings.TrimPrefix(apiURL, repoAPIURL)
+
+	switch {
+	case strings.HasPrefix(path, "/issues/"):
+		return repoHTMLURL + path
+	case strings.HasPrefix(path, "/pulls/"):
+		return repoHTMLURL + "/pull/" + strings.TrimPrefix(path, "/pulls/")
+	case strings.HasPrefix(path, "/commits/"):
+		return repoHTMLURL + "/commit/" + strings.TrimPrefix(path, "/commits/")
+	}
+
+	return ""
 }
 
-func githubNotify(ch *chan int, ctx context.Context, callback func([]*github.Notification, error)) {
-	defer processEnd(ch)
+func addNotifications(notifications []*github.Notification, err error) {
+	if err != nil {
+
+		// keep showing the last fetch while offline, actions are queued
+		if notificationStore.Len() != 0 {
+			return
+		}
+
+		var tokenErr *TokenError
 
-	notifications, err := fetchNotifications(ctx)
+		if errors.As(tokenError(err), &tokenErr) {
+			windowContentRefresh(tokenErr.Message + "\n" + tr("Update it in Settings."))
+			return
+		}
 
-	select {
-	case <-ctx.Done():
-		log.Println("Context canceled")
+		windowContentRefresh(tr("Failed to fetch notifications"))
 		return
-	default:
-		callback(notifications, err)
 	}
 
-	// showNotifications(notifications)
-}
+	reconcileOutbox(notifications)
 
-func githubNotifyLoop(ctx context.Context) {
-	ch := make(chan int)
+	change := notificationStore.Set(applyPendingActions(notifications))
 
-	log.Println("Start github notification loop")
+	if len(change.Removed) != 0 {
+		pruneBulkSelection()
+	}
 
-	for {
-		go githubNotify(&ch, ctx, addNotifications)
+	if isShowAllNotifications() {
+		windowContentRefresh(tr("No Notifications"))
+	} else {
+		windowContentRefresh(tr("No New Notifications"))
+	}
 
-		waitForProcess(&ch)
+	restoreLastView()
 
-		// check if context is canceled
-		select {
-		case <-ctx.Done():
-			log.Println("Context canceled")
-			return
-		default:
-			log.Println("Wait for next loop")
-			time.Sleep(REPEAT_TIME)
+	notificationsDiff := filterUnread(change.Inserted)
+
+	if len(notificationsDiff) != 0 && !isDoNotDisturb(time.Now()) {
+		recordActivity()
+
+		_ = toast.Push("Github Notifications",
+			toast.WithTitle(trn("You have %d new notification", "You have %d new notifications", len(notificationsDiff))),
+			toast.WithObjectiveC(true),
+		)
+	}
+
+	// every time, so runs dropped on a full queue are tried again
+	runHooks(globalCtx, filterUnread(notifications))
+}
+
+func filterUnread(notifications []*github.Notification) []*github.Notification {
+	var unread []*github.Notification
+
+	for _, notification := range notifications {
+		if notification.GetUnread() {
+			unread = append(unread, notification)
 		}
 	}
+
+	return unread
 }
 
-func openSettingsPanel() {
-	githubTokenEntry := widget.NewEntry()
-	githubTokenEntry.SetPlaceHolder("Enter Github Token")
-	githubTokenEntry.SetText(notifierApp.Preferences().String("github_token"))
+func isShowAllNotifications() bool {
+	return notifierApp.Preferences().BoolWithFallback("show_all", false)
+}
 
-	spacer := canvas.NewRectangle(color.NRGBA{0x00, 0x00, 0x00, 0x00})
-	spacer.SetMinSize(fyne.NewSize(0, 10))
+func startNotifyLoop() {
+	taskSupervisor.Start(NOTIFY_TASK, RestartOnFailure, githubNotifyLoop)
+}
 
-	dialog := dialog.NewForm(
-		"App Settings",
-		"Save",
-		"Cancel",
-		[]*widget.FormItem{
-			widget.NewFormItem("Token", githubTokenEntry),
-			widget.NewFormItem("", spacer),
-		},
-		func(isSave bool) {
-			old_github_token := notifierApp.Preferences().String("github_token")
+func refreshNotifications() {
+	recordActivity()
+	taskSupervisor.Trigger(NOTIFY_TASK)
+}
 
-			if isSave {
-				notifierApp.Preferences().SetString("github_token", githubTokenEntry.Text)
-			}
+func githubNotifyLoop(ctx context.Context, refresh <-chan struct{}) error {
+	slog.Info("Notification loop started")
 
-			new_github_token := notifierApp.Preferences().String("github_token")
+	for {
+		notifications, err := fetchNotifications(ctx)
 
-			if new_github_token == "" {
-				notifierApp.Quit()
-			}
+		// check if context is canceled
+		if ctx.Err() != nil {
+			slog.Info("Notification loop stopped")
+			return nil
+		}
 
-			if old_github_token != new_github_token {
-				startAsyncProcess("githubNotifyLoop", githubNotifyLoop)
-			}
-		},
-		window,
-	)
+		runOnUI(func() {
+			addNotifications(notifications, err)
+		})
 
-	dialog.Resize(fyne.NewSize(300, 150))
-	dialog.Show()
+		interval := nextPollInterval()
+		recordNextPoll(time.Now().Add(interval))
+		slog.Debug("Waiting for next poll", "interval", interval)
+
+		select {
+		case <-ctx.Done():
+			slog.Info("Notification loop stopped")
+			return nil
+		case <-refresh:
+			slog.Debug("Refresh requested")
+		case <-time.After(interval):
+		}
+	}
 }
 
 func addNotificationListUI() *widget.List {
-	return widget.NewList(
+	list := widget.NewList(
 		func() int {
-			return len(notificationList)
+			return notificationStore.Len()
 		},
 		func() fyne.CanvasObject {
 			return NewModernUI()
 		},
 		func(id widget.ListItemID, item fyne.CanvasObject) {
-			title := notificationList[id].GetRepository().GetFullName()
-			ntype := notificationList[id].GetReason()
-			content := notificationList[id].GetSubject().GetTitle()
-			time := notificationList[id].GetUpdatedAt().Time
-			url := notificationList[id].GetRepository().GetHTMLURL()
-			avatarURL := notificationList[id].GetRepository().GetOwner().GetAvatarURL()
-
-			if avatarURL != "" {
-				avatarURL += "&s=40"
+			notification := notificationStore.At(id)
+
+			if notification == nil {
+				return
 			}
 
+			title := notification.GetRepository().GetFullName()
+			ntype := notification.GetReason()
+			content := notification.GetSubject().GetTitle()
+			time := notification.GetUpdatedAt().Time
+			avatarURL := notification.GetRepository().GetOwner().GetAvatarURL()
+
 			modernUI := item.(*ModernUI)
-			modernUI.SetStatus(false)
-			modernUI.SetProfileImage(avatarURL)
+			modernUI.SetStatus(!notification.GetUnread())
+			modernUI.SetSubjectType(notification.GetSubject().GetType())
+			modernUI.SetSelected(isBulkSelected(notification.GetID()))
+			modernUI.SetSelectCallback(func(selected bool) {
+				setBulkSelected(notification.GetID(), selected)
+			})
+			modernUI.SetShiftClickCallback(func() {
+				selectBulkRange(notification.GetID())
+			})
 			modernUI.SetType(ntype)
 			modernUI.SetProfileName(title)
 			modernUI.SetMessage(content)
+			modernUI.SetMessageLines(titleLines())
 			modernUI.SetTime(time)
+			modernUI.SetState("")
+			modernUI.SetStateName("")
+			modernUI.SetDetails("")
+
+			notificationID := notification.GetID()
+			details := getSubjectDetails(notification, func(*SubjectDetails) {
+				runOnUI(func() {
+					if index := notificationStore.IndexOf(notificationID); index != -1 {
+						notificationListComponent.RefreshItem(index)
+					}
+				})
+			})
+
+			if details != nil {
+				modernUI.SetState(details.StateText())
+				modernUI.SetStateName(details.State)
+				modernUI.SetDetails(details.Summary())
+				avatarURL = details.ActorAvatarURL(avatarURL)
+			}
+
+			modernUI.SetProfileImage(sizedAvatarURL(avatarURL, 40))
 			modernUI.SetOpenCallback(func(btn *widget.Button) {
+				url := notificationHTMLURL(notification)
+
 				if url == "" {
 					return
 				}
@@ -282,20 +467,84 @@ func addNotificationListUI() *widget.List {
 				btn.Enable()
 			})
 			modernUI.SetReadCallback(func(btn *widget.Button) {
-				btn.Disable()
-
-				isRead, _ := markAsReadNotification(notificationList[id])
-
-				if isRead {
-					btn.Hide()
-					startAsyncProcess("githubNotifyLoop", githubNotifyLoop)
-				}
-				btn.Enable()
+				btn.Hide()
+				readNotificationAction(notification)
 			})
 
 			modernUI.Refresh()
 		},
 	)
+
+	list.OnSelected = func(id widget.ListItemID) {
+		notification := notificationStore.At(id)
+
+		if notification == nil {
+			return
+		}
+
+		selectedNotificationID, _ := notificationStore.Selected()
+		notificationStore.SetSelected(notification.GetID(), id)
+
+		if selectedNotificationID != notification.GetID() {
+			showNotificationDetail(notification)
+		}
+	}
+
+	list.OnUnselected = func(id widget.ListItemID) {
+		notificationStore.SetSelected("", -1)
+		hideNotificationDetail()
+	}
+
+	return list
+}
+
+func sizedAvatarURL(avatarURL string, size int) string {
+	if avatarURL == "" {
+		return ""
+	}
+
+	if strings.Contains(avatarURL, "?") {
+		return fmt.Sprintf("%s&s=%d", avatarURL, size)
+	}
+
+	return fmt.Sprintf("%s?s=%d", avatarURL, size)
+}
+
+func onNotificationStoreChange(change StoreChange) {
+	if len(change.Removed) != 0 || len(change.Updated) != 0 {
+		pruneSubjectDetails(notificationStore.All())
+	}
+
+	if change.IsStructural() {
+		pruneBulkSelection()
+		notificationListComponent.Refresh()
+		restoreSelection()
+		return
+	}
+
+	for _, notification := range change.Updated {
+		notificationListComponent.RefreshItem(notificationStore.IndexOf(notification.GetID()))
+		refreshNotificationDetail(notification.GetID())
+	}
+}
+
+func restoreSelection() {
+	selectedNotificationID, selectedNotificationIndex := notificationStore.Selected()
+
+	if selectedNotificationID == "" {
+		return
+	}
+
+	index := notificationStore.IndexOf(selectedNotificationID)
+
+	if index == -1 {
+		notificationListComponent.UnselectAll()
+		return
+	}
+
+	if index != selectedNotificationIndex {
+		notificationListComponent.Select(index)
+	}
 }
 
 func addToolbarUI() fyne.CanvasObject {
@@ -308,44 +557,121 @@ func addToolbarUI() fyne.CanvasObject {
 		},
 	)
 
-	toolbar := widget.NewToolbar(preference)
+	showAll := widget.NewToolbarAction(showAllIcon(), func() {
+		setShowAllNotifications(!isShowAllNotifications())
+	})
+	showAllAction = showAll
+
+	search := widget.NewToolbarAction(theme.SearchIcon(), func() {
+		if searchEntry.Visible() {
+			closeSearch()
+		} else {
+			openSearch()
+		}
+	})
+
+	toolbar = widget.NewToolbar(showAll, search, widget.NewToolbarSpacer(), preference)
 	toolbar.Resize(fyne.NewSize(400, 50))
 
 	return toolbar
 }
 
-func wrapperContainer(list *widget.List, altMessage string) fyne.CanvasObject {
-	if list.Length() == 0 {
-		label := widget.NewLabel(altMessage)
-		label.Alignment = fyne.TextAlignCenter
-		label.TextStyle.Bold = true
+func setShowAllNotifications(showAll bool) {
+	notifierApp.Preferences().SetBool("show_all", showAll)
+
+	showAllAction.SetIcon(showAllIcon())
+	toolbar.Refresh()
 
-		return container.NewCenter(label)
+	refreshNotifications()
+}
+
+func showAllIcon() fyne.Resource {
+	if isShowAllNotifications() {
+		return theme.VisibilityIcon()
 	}
 
-	return list
+	return theme.VisibilityOffIcon()
 }
 
-func windowContentRefresh(altMessage string) {
-	notificationListComponent = addNotificationListUI()
+func windowContentInit() {
+	altMessageLabel = widget.NewLabel("")
+	altMessageLabel.Alignment = fyne.TextAlignCenter
+	altMessageLabel.TextStyle.Bold = true
 
 	mainContainer := container.NewBorder(
-		addToolbarUI(),
-		nil,
+		container.NewVBox(addToolbarUI(), addSearchUI(), addBulkActionsUI()),
+		addSnackbarUI(),
 		nil,
 		nil,
-		wrapperContainer(notificationListComponent, altMessage),
+		addDetailPaneUI(container.NewStack(notificationListComponent, container.NewCenter(altMessageLabel))),
 	)
 
 	window.SetContent(mainContainer)
 }
 
+func addSearchUI() fyne.CanvasObject {
+	searchEntry = widget.NewEntry()
+	searchEntry.SetPlaceHolder(tr("Search notifications"))
+	searchEntry.OnChanged = func(query string) {
+		setSearchQuery(query)
+	}
+	searchEntry.OnSubmitted = func(string) {
+		window.Canvas().Unfocus()
+	}
+	searchEntry.Hide()
+
+	return searchEntry
+}
+
+func openSearch() {
+	searchEntry.Show()
+	window.Canvas().Focus(searchEntry)
+}
+
+func closeSearch() {
+	if !searchEntry.Visible() {
+		return
+	}
+
+	searchEntry.SetText("")
+	searchEntry.Hide()
+	window.Canvas().Unfocus()
+}
+
+func windowContentRefresh(altMessage string) {
+	altMessageLabel.SetText(altMessage)
+
+	if notificationStore.Len() == 0 {
+		notificationListComponent.Hide()
+		altMessageLabel.Show()
+		return
+	}
+
+	altMessageLabel.Hide()
+	notificationListComponent.Show()
+}
+
 func addSystemStrayMenu() {
 	menu := fyne.NewMenu("GitHub Notify",
-		fyne.NewMenuItem("Show", func() {
-			window.Show()
+		fyne.NewMenuItem(tr("Show"), func() {
+			showWindow()
+		}),
+		fyne.NewMenuItem(tr("Hook Log"), func() {
+			openHookLogPanel()
 		}),
-		fyne.NewMenuItem("Quit", func() {
+		fyne.NewMenuItem(tr("Background Tasks"), func() {
+			openTaskStatusPanel()
+		}),
+		fyne.NewMenuItem(tr("Diagnostics"), func() {
+			openDiagnosticsPanel()
+		}),
+		fyne.NewMenuItem(tr("Quit"), func() {
+			if isWindowVisible() {
+				saveWindowState()
+			}
+
+			flushPendingActions()
+			taskSupervisor.StopAll()
 			notifierApp.Quit()
 		}),
 	)
@@ -356,17 +682,20 @@ func addSystemStrayMenu() {
 	}
 }
 
-func startAsyncProcess(name string, process func(ctx context.Context)) {
-	if ctxCancelFunc, ok := ctxMap[name]; ok {
-		log.Println("Cancel process", name)
-		(*ctxCancelFunc)()
-	}
+func openHookLogPanel() {
+	hookLogLabel := widget.NewLabel(hookLogText())
+	hookLogLabel.Wrapping = fyne.TextWrapWord
 
-	ctx, cancel := context.WithCancel(globalCtx)
+	scroll := container.NewVScroll(hookLogLabel)
+	scroll.SetMinSize(fyne.NewSize(360, 300))
 
-	ctxMap[name] = &cancel
+	dialog.ShowCustom(tr("Hook Log"), tr("Close"), scroll, window)
+	showWindow()
+}
 
-	go process(ctx)
+func openTaskStatusPanel() {
+	dialog.ShowInformation(tr("Background Tasks"), taskStatusText()+"\n\n"+tr("Outbox")+"\n"+outboxText(), window)
+	showWindow()
 }
 
 func openURLInBrowser(url string) {
@@ -380,10 +709,10 @@ func openURLInBrowser(url string) {
 	case "windows":
 		err = exec.Command("cmd", "/c", "start", url).Start()
 	default:
-		log.Println("Unsupported platform")
+		slog.Warn("Cannot open URLs on this platform", "os", runtime.GOOS)
 	}
 
 	if err != nil {
-		log.Println(err)
+		slog.Warn("Failed to open URL", "url", url, "err", err)
 	}
 }
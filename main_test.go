@@ -0,0 +1,315 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+	"github.com/google/go-github/v55/github"
+)
+
+// A 1x1 transparent PNG served as every avatar.
+const TEST_AVATAR_PNG string = "iVBORw0KGgoAAAANSUhEUgAAAAEAAAABCAQAAAC1HAwCAAAAC0lEQVR42mNkYAAAAAYAAjCB0C8AAAAASUVORK5CYII="
+
+// fakeGitHub serves the parts of the GitHub API the app uses.
+type fakeGitHub struct {
+	sync.Mutex
+	server        *httptest.Server
+	notifications []*github.Notification
+	requests      map[string]int
+	// threadDelay slows down read, done and unsubscribe requests
+	threadDelay time.Duration
+	// failDetails fails every issue and pull request request
+	failDetails bool
+	// fineGrained leaves the scopes out, as for fine-grained tokens
+	fineGrained bool
+	// notificationsStatus fails listing notifications with this status
+	notificationsStatus int
+}
+
+// testGitHub is shared by all tests, as background fetches of one test can
+// outlive it.
+var testGitHub *fakeGitHub
+
+func newFakeGitHub() *fakeGitHub {
+	f := &fakeGitHub{requests: make(map[string]int)}
+	f.server = httptest.NewServer(http.HandlerFunc(f.serve))
+
+	return f
+}
+
+func (f *fakeGitHub) reset(count int) {
+	f.Lock()
+	defer f.Unlock()
+
+	f.requests = make(map[string]int)
+	f.notifications = nil
+	f.threadDelay = 0
+	f.failDetails = false
+	f.fineGrained = false
+	f.notificationsStatus = 0
+
+	for i := 1; i <= count; i++ {
+		f.notifications = append(f.notifications, f.notification(i))
+	}
+}
+
+func (f *fakeGitHub) notification(i int) *github.Notification {
+	id := fmt.Sprint(i)
+	unread := true
+	updated := github.Timestamp{Time: time.Now().Add(-time.Duration(i) * time.Minute).Truncate(time.Second)}
+
+	return &github.Notification{
+		ID:        &id,
+		Unread:    &unread,
+		Reason:    github.String("mention"),
+		UpdatedAt: &updated,
+		Subject: &github.NotificationSubject{
+			Title: github.String("Notification " + id),
+			Type:  github.String("Issue"),
+			URL:   github.String(f.server.URL + "/repos/octo/hello/issues/" + id),
+		},
+		Repository: &github.Repository{
+			Name:     github.String("hello"),
+			FullName: github.String("octo/hello"),
+			HTMLURL:  github.String("https://github.com/octo/hello"),
+			Owner: &github.User{
+				Login:     github.String("octo"),
+				AvatarURL: github.String(f.server.URL + "/avatars/" + id),
+			},
+		},
+	}
+}
+
+func (f *fakeGitHub) serve(w http.ResponseWriter, r *http.Request) {
+	f.Lock()
+	f.requests[r.Method+" "+r.URL.Path]++
+	notifications := append([]*github.Notification(nil), f.notifications...)
+	threadDelay := f.threadDelay
+	failDetails := f.failDetails
+	fineGrained := f.fineGrained
+	notificationsStatus := f.notificationsStatus
+	f.Unlock()
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/notifications" && notificationsStatus != 0:
+		w.WriteHeader(notificationsStatus)
+		_, _ = w.Write([]byte(`{"message": "failed"}`))
+	case r.Method == http.MethodGet && r.URL.Path == "/notifications":
+		if r.URL.Query().Get("all") != "true" {
+			notifications = filterUnread(notifications)
+		}
+
+		_ = json.NewEncoder(w).Encode(notifications)
+	case r.Method == http.MethodGet && r.URL.Path == "/user":
+		if !fineGrained {
+			w.Header().Set("X-OAuth-Scopes", "notifications, repo")
+		}
+
+		_ = json.NewEncoder(w).Encode(&github.User{Login: github.String("octo")})
+	case strings.HasPrefix(r.URL.Path, "/avatars/"):
+		png, _ := base64.StdEncoding.DecodeString(TEST_AVATAR_PNG)
+		w.Header().Set("Content-Type", "image/png")
+		_, _ = w.Write(png)
+	case strings.HasPrefix(r.URL.Path, "/notifications/threads/"):
+		select {
+		case <-time.After(threadDelay):
+		case <-r.Context().Done():
+			return
+		}
+
+		w.WriteHeader(http.StatusResetContent)
+	case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/comments"):
+		_, _ = w.Write([]byte("[]"))
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/repos/") && failDetails:
+		w.WriteHeader(http.StatusInternalServerError)
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/repos/"):
+		_ = json.NewEncoder(w).Encode(&github.Issue{
+			State: github.String("open"),
+			Body:  github.String("Body"),
+			User:  &github.User{Login: github.String("octo")},
+		})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (f *fakeGitHub) requestCount(key string) int {
+	f.Lock()
+	defer f.Unlock()
+
+	return f.requests[key]
+}
+
+// TestMain sets the app up the way main does, against a test app, so every
+// test can drive the UI through the same globals.
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "notify-test")
+
+	if err != nil {
+		panic(err)
+	}
+
+	os.Setenv("HOME", dir)
+	os.Setenv("XDG_CONFIG_HOME", dir+"/config")
+	os.Setenv("XDG_CACHE_HOME", dir+"/cache")
+	os.Setenv("XDG_DATA_HOME", dir+"/data")
+
+	notifierApp = test.NewApp()
+	startUIEventLoop()
+
+	setLocale("en")
+	window = notifierApp.NewWindow("test")
+	window.Resize(fyne.NewSize(WINDOW_WIDTH, WINDOW_HEIGHT))
+
+	notificationStore = newNotificationStore()
+	notificationStore.SetFilter(notificationFilter)
+	notificationStore.AddListener(onNotificationStoreChange)
+
+	notificationListComponent = addNotificationListUI()
+
+	windowContentInit()
+	windowContentRefresh("Loading...")
+
+	globalCtx = context.Background()
+	taskSupervisor = newTaskSupervisor(globalCtx)
+
+	testGitHub = newFakeGitHub()
+	githubAPIURL = testGitHub.server.URL + "/"
+
+	code := m.Run()
+
+	taskSupervisor.StopAll()
+	testGitHub.server.Close()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+// useFakeGitHub points the app at a fake API with a token configured.
+func useFakeGitHub(t *testing.T, count int) *fakeGitHub {
+	testGitHub.reset(count)
+
+	appConfig.Lock()
+	appConfig.file = defaultConfig()
+	appConfig.file.Accounts = []AccountConfig{{Name: "default", Token: "test-token"}}
+	appConfig.effective = appConfig.file
+	appConfig.Unlock()
+
+	t.Cleanup(func() {
+		taskSupervisor.Stop(NOTIFY_TASK)
+		eventually(t, 10*time.Second, func() bool {
+			for _, info := range taskSupervisor.Status() {
+				if info.Name == NOTIFY_TASK && info.Status != TaskStopped {
+					return false
+				}
+			}
+
+			return true
+		})
+		// listeners update the list, which only the UI loop may touch
+		runOnUI(func() {
+			notificationStore.Set(nil)
+		})
+		waitUI(t)
+	})
+
+	return testGitHub
+}
+
+// waitUI waits until every UI event queued so far has run.
+func waitUI(t *testing.T) {
+	t.Helper()
+
+	done := make(chan struct{})
+	runOnUI(func() { close(done) })
+
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("UI event loop is stuck")
+	}
+}
+
+// eventually polls condition until it holds or the timeout passes.
+func eventually(t *testing.T, timeout time.Duration, condition func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(timeout)
+
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met in time")
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestFetchNotificationsShowAll(t *testing.T) {
+	fake := useFakeGitHub(t, 3)
+	fake.Lock()
+	fake.notifications[1].Unread = github.Bool(false)
+	fake.Unlock()
+
+	t.Cleanup(func() {
+		notifierApp.Preferences().SetBool("show_all", false)
+	})
+
+	tests := []struct {
+		showAll bool
+		want    []string
+	}{
+		{false, []string{"1", "3"}},
+		{true, []string{"1", "2", "3"}},
+	}
+
+	for _, test := range tests {
+		notifierApp.Preferences().SetBool("show_all", test.showAll)
+
+		notifications, err := fetchNotifications(context.Background())
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var ids []string
+
+		for _, notification := range notifications {
+			ids = append(ids, notification.GetID())
+		}
+
+		if !reflect.DeepEqual(ids, test.want) {
+			t.Errorf("show all %v fetched %q, want %q", test.showAll, ids, test.want)
+		}
+
+		if unread := filterUnread(notifications); len(unread) != 2 {
+			t.Errorf("show all %v: %d unread, want 2", test.showAll, len(unread))
+		}
+	}
+}
+
+func TestSizedAvatarURL(t *testing.T) {
+	tests := map[string]string{
+		"": "",
+		"https://avatars.githubusercontent.com/u/1":      "https://avatars.githubusercontent.com/u/1?s=64",
+		"https://avatars.githubusercontent.com/u/1?v=4":  "https://avatars.githubusercontent.com/u/1?v=4&s=64",
+		"https://avatars.githubusercontent.com/in/15368": "https://avatars.githubusercontent.com/in/15368?s=64",
+	}
+
+	for avatarURL, want := range tests {
+		if got := sizedAvatarURL(avatarURL, 64); got != want {
+			t.Errorf("sizedAvatarURL(%q) = %q, want %q", avatarURL, got, want)
+		}
+	}
+}
@@ -1,21 +1,27 @@
 package main
 
 import (
-	"fmt"
+	"image/color"
+	"log/slog"
+	"sync"
 	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/driver/desktop"
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
 )
 
-var imageCache map[string]fyne.Resource
+const MAX_TITLE_LINES int = 3
+
+var avatarCache *imageCache
+var avatarCacheOnce sync.Once
 
 func NewModernUI() fyne.CanvasObject {
-	if imageCache == nil {
-		imageCache = make(map[string]fyne.Resource)
-	}
+	avatarCacheOnce.Do(func() {
+		avatarCache = newImageCache(IMAGE_CACHE_SIZE, IMAGE_LOADER_WORKERS, imageCacheDir())
+	})
 
 	modernUI := &ModernUI{
 		Status:       true,
@@ -24,11 +30,12 @@ func NewModernUI() fyne.CanvasObject {
 		Type:         "Issue",
 		Message:      "GitHub is how people build software. Millions of developers and companies build, ship, and maintain their software on GitHub—the largest and most advanced development platform in the world.",
 		Time:         time.Now(),
+		MessageLines: titleLines(),
 		OpenCallback: func(btn *widget.Button) {
-			fmt.Println("Open")
+			slog.Debug("Open tapped")
 		},
 		ReadCallback: func(btn *widget.Button) {
-			fmt.Println("Read")
+			slog.Debug("Read tapped")
 		},
 	}
 
@@ -38,24 +45,47 @@ func NewModernUI() fyne.CanvasObject {
 
 type ModernUI struct {
 	widget.BaseWidget
-	Status       bool
-	ProfileImage string
-	ProfileName  string
-	Type         string
-	Message      string
-	Time         time.Time
-	OpenCallback func(*widget.Button)
-	ReadCallback func(*widget.Button)
+	Status             bool
+	ProfileImage       string
+	ProfileName        string
+	Type               string
+	Message            string
+	MessageLines       int
+	Time               time.Time
+	State              string
+	StateName          string
+	Details            string
+	SubjectType        string
+	Selected           bool
+	OpenCallback       func(*widget.Button)
+	ReadCallback       func(*widget.Button)
+	SelectCallback     func(bool)
+	ShiftClickCallback func()
+
+	// the avatar callback compares ProfileImage from the UI event loop
+	profileMutex sync.Mutex
 }
 
+var _ desktop.Mouseable = (*ModernUI)(nil)
+
 func (m *ModernUI) SetStatus(status bool) {
 	m.Status = status
 }
 
 func (m *ModernUI) SetProfileImage(profileImage string) {
+	m.profileMutex.Lock()
+	defer m.profileMutex.Unlock()
+
 	m.ProfileImage = profileImage
 }
 
+func (m *ModernUI) profileImage() string {
+	m.profileMutex.Lock()
+	defer m.profileMutex.Unlock()
+
+	return m.ProfileImage
+}
+
 func (m *ModernUI) SetProfileName(profileName string) {
 	m.ProfileName = profileName
 }
@@ -68,10 +98,53 @@ func (m *ModernUI) SetMessage(message string) {
 	m.Message = message
 }
 
+func (m *ModernUI) SetMessageLines(lines int) {
+	m.MessageLines = lines
+}
+
 func (m *ModernUI) SetTime(time time.Time) {
 	m.Time = time
 }
 
+func (m *ModernUI) SetState(state string) {
+	m.State = state
+}
+
+// SetStateName sets the untranslated state, like open or merged, which
+// picks the color of the state text.
+func (m *ModernUI) SetStateName(name string) {
+	m.StateName = name
+}
+
+func (m *ModernUI) SetDetails(details string) {
+	m.Details = details
+}
+
+func (m *ModernUI) SetSubjectType(subjectType string) {
+	m.SubjectType = subjectType
+}
+
+func (m *ModernUI) SetSelected(selected bool) {
+	m.Selected = selected
+}
+
+func (m *ModernUI) SetSelectCallback(selectCallback func(bool)) {
+	m.SelectCallback = selectCallback
+}
+
+func (m *ModernUI) SetShiftClickCallback(shiftClickCallback func()) {
+	m.ShiftClickCallback = shiftClickCallback
+}
+
+func (m *ModernUI) MouseDown(event *desktop.MouseEvent) {
+	if event.Modifier&fyne.KeyModifierShift != 0 && m.ShiftClickCallback != nil {
+		m.ShiftClickCallback()
+	}
+}
+
+func (m *ModernUI) MouseUp(*desktop.MouseEvent) {
+}
+
 func (m *ModernUI) SetOpenCallback(openCallback func(*widget.Button)) {
 	m.OpenCallback = openCallback
 }
@@ -85,84 +158,105 @@ func (m *ModernUI) MinSize() fyne.Size {
 }
 
 func (m *ModernUI) CreateRenderer() fyne.WidgetRenderer {
-	padding := theme.Padding()
-
-	statusColor := fyne.CurrentApp().Settings().Theme().Color("StatusRead", theme.VariantLight)
-	if !m.Status {
-		statusColor = fyne.CurrentApp().Settings().Theme().Color("StatusUnread", theme.VariantLight)
+	status := canvas.NewCircle(color.Transparent)
+
+	check := widget.NewCheck("", nil)
+	check.SetChecked(m.Selected)
+	check.OnChanged = func(checked bool) {
+		if m.SelectCallback != nil && checked != m.Selected {
+			m.Selected = checked
+			m.SelectCallback(checked)
+		}
 	}
 
-	status := canvas.NewCircle(statusColor)
-	status.Resize(fyne.NewSize(8, 8))
-
 	githubIcon := fyne.CurrentApp().Settings().Theme().Icon("GitHub")
 
 	image := canvas.NewImageFromResource(githubIcon)
 	image.FillMode = canvas.ImageFillContain
-	image.Resize(fyne.NewSize(40, 40))
+
+	typeBadge := canvas.NewCircle(theme.BackgroundColor())
+
+	typeIcon := canvas.NewImageFromResource(subjectTypeIcon(m.SubjectType))
+	typeIcon.FillMode = canvas.ImageFillContain
 
 	name := canvas.NewText(m.ProfileName, theme.ForegroundColor())
 	name.TextStyle.Bold = true
 	name.Resize(name.MinSize())
-	name.Text = trimmedText(m.ProfileName, name.Size().Width, &fyne.TextStyle{Bold: true})
-	name.Refresh()
 
-	ntypeColor := fyne.CurrentApp().Settings().Theme().Color("NType", theme.VariantLight)
+	ntypeColor := themeColor("NType")
 	ntype := canvas.NewText(m.Type, ntypeColor)
 	ntype.Resize(ntype.MinSize())
 
-	message := canvas.NewText(m.Message, theme.ForegroundColor())
-	message.Resize(message.MinSize())
-	message.Text = trimmedText(m.Message, message.Size().Width, &fyne.TextStyle{})
-	message.Refresh()
+	state := canvas.NewText(m.State, stateColor(m.StateName))
+	state.TextStyle.Bold = true
+	state.Resize(state.MinSize())
+
+	message := make([]*canvas.Text, MAX_TITLE_LINES)
 
-	timeColor := fyne.CurrentApp().Settings().Theme().Color("Time", theme.VariantLight)
+	for i := range message {
+		message[i] = canvas.NewText("", theme.ForegroundColor())
+	}
+
+	details := canvas.NewText(m.Details, ntypeColor)
+
+	timeColor := themeColor("Time")
 	time := canvas.NewText(convertTimeToTimeAgo(m.Time), timeColor)
 	time.Alignment = fyne.TextAlignTrailing
 	time.TextStyle.Italic = true
 	time.Resize(time.MinSize())
 
-	readBtn := widget.NewButton("Read", nil)
-	readBtn.OnTapped = func() {
-		m.ReadCallback(readBtn)
+	readBtn := widget.NewButton(tr("Read"), nil)
 
-		m.Status = true
-		status.FillColor = fyne.CurrentApp().Settings().Theme().Color("StatusRead", theme.VariantLight)
-		status.Refresh()
-	}
-	readBtn.Resize(fyne.NewSize(readBtn.MinSize().Width+padding, 7*padding))
-
-	openBtn := widget.NewButton("Open", nil)
+	openBtn := widget.NewButton(tr("Open"), nil)
 	openBtn.OnTapped = func() {
 		m.OpenCallback(openBtn)
 	}
-	openBtn.Resize(fyne.NewSize(openBtn.MinSize().Width+padding, 7*padding))
 
 	modernUIRendererObj := &modernUIRenderer{
-		ModernUI: m,
-		status:   status,
-		image:    image,
-		name:     name,
-		ntype:    ntype,
-		message:  message,
-		time:     time,
-		readBtn:  readBtn,
-		openBtn:  openBtn,
+		ModernUI:  m,
+		status:    status,
+		check:     check,
+		image:     image,
+		typeBadge: typeBadge,
+		typeIcon:  typeIcon,
+		name:      name,
+		ntype:     ntype,
+		state:     state,
+		message:   message,
+		details:   details,
+		time:      time,
+		readBtn:   readBtn,
+		openBtn:   openBtn,
+	}
+
+	readBtn.OnTapped = func() {
+		m.ReadCallback(readBtn)
+
+		m.Status = true
+		modernUIRendererObj.applyStatus()
 	}
 
+	modernUIRendererObj.applySizes()
+	modernUIRendererObj.applyStatus()
+
 	return modernUIRendererObj
 }
 
 type modernUIRenderer struct {
-	ModernUI *ModernUI
-	status   *canvas.Circle
-	image    *canvas.Image
-	name     *canvas.Text
-	ntype    *canvas.Text
-	message  *canvas.Text
-	time     *canvas.Text
-	readBtn  *widget.Button
-	openBtn  *widget.Button
+	ModernUI  *ModernUI
+	status    *canvas.Circle
+	check     *widget.Check
+	image     *canvas.Image
+	typeBadge *canvas.Circle
+	typeIcon  *canvas.Image
+	name      *canvas.Text
+	ntype     *canvas.Text
+	state     *canvas.Text
+	message   []*canvas.Text
+	details   *canvas.Text
+	time      *canvas.Text
+	readBtn   *widget.Button
+	openBtn   *widget.Button
 }
 
 func (m *modernUIRenderer) Destroy() {
@@ -180,6 +274,7 @@ func (m *modernUIRenderer) MinSize() fyne.Size {
 
 	width += 4 * padding
 
+	width += m.check.Size().Width
 	width += m.status.Size().Width
 	width += m.image.Size().Width
 	width += m.name.Size().Width
@@ -187,54 +282,189 @@ func (m *modernUIRenderer) MinSize() fyne.Size {
 	height += 3.5 * padding
 
 	height += m.image.Size().Height
-	height += m.message.Size().Height
+	height += float32(m.messageLines()) * m.message[0].MinSize().Height
+	height += m.details.Size().Height
 	height += m.time.Size().Height
 
 	return fyne.NewSize(width, height)
 }
 
 func (m *modernUIRenderer) Objects() []fyne.CanvasObject {
-	return []fyne.CanvasObject{
+	objects := []fyne.CanvasObject{
+		m.check,
 		m.status,
 		m.image,
+		m.typeBadge,
+		m.typeIcon,
 		m.name,
 		m.ntype,
-		m.message,
+		m.state,
+		m.details,
 		m.time,
 		m.readBtn,
 		m.openBtn,
 	}
+
+	for _, line := range m.message {
+		objects = append(objects, line)
+	}
+
+	return objects
 }
 
-func (m *modernUIRenderer) Refresh() {
-	m.status.FillColor = fyne.CurrentApp().Settings().Theme().Color("StatusRead", theme.VariantLight)
+func (m *modernUIRenderer) messageLines() int {
+	lines := m.ModernUI.MessageLines
 
-	if !m.ModernUI.Status {
-		m.status.FillColor = fyne.CurrentApp().Settings().Theme().Color("StatusUnread", theme.VariantLight)
+	if lines < 1 {
+		return 1
+	}
+
+	if lines > MAX_TITLE_LINES {
+		return MAX_TITLE_LINES
+	}
+
+	return lines
+}
+
+// layoutMessage wraps the title over the configured number of lines.
+func (m *modernUIRenderer) layoutMessage(width float32) {
+	style := m.message[0].TextStyle
+	lines := wrapText(m.ModernUI.Message, width, m.message[0].TextSize, style, m.messageLines())
+
+	for i, line := range m.message {
+		line.Text = ""
+
+		if i < len(lines) {
+			line.Text = lines[i]
+		}
+
+		line.Refresh()
+	}
+}
+
+// applySizes sizes the row's parts from the theme's text size, so rows grow
+// with Fyne's scale setting and the text size chosen in the settings.
+func (m *modernUIRenderer) applySizes() {
+	textSize := theme.TextSize()
+
+	m.status.Resize(fyne.NewSize(textSize*0.6, textSize*0.6))
+	m.image.Resize(fyne.NewSize(textSize*3, textSize*3))
+	m.typeBadge.Resize(fyne.NewSize(textSize*1.3, textSize*1.3))
+	m.typeIcon.Resize(fyne.NewSize(textSize*0.85, textSize*0.85))
+
+	for _, text := range append([]*canvas.Text{m.name, m.ntype, m.state, m.time}, m.message...) {
+		text.TextSize = textSize
+	}
+
+	m.details.TextSize = theme.CaptionTextSize()
+
+	m.name.Resize(fyne.NewSize(m.name.Size().Width, m.name.MinSize().Height))
+
+	for _, line := range m.message {
+		line.Resize(fyne.NewSize(line.Size().Width, line.MinSize().Height))
+	}
+	m.details.Resize(fyne.NewSize(m.details.Size().Width, m.details.MinSize().Height))
+	m.time.Resize(fyne.NewSize(m.time.Size().Width, m.time.MinSize().Height))
+
+	m.check.Resize(m.check.MinSize())
+	m.readBtn.Resize(m.readBtn.MinSize())
+	m.openBtn.Resize(m.openBtn.MinSize())
+}
+
+// applyStatus shows unread rows with a filled dot and bold text, and read
+// ones with a hollow ring, so the state does not rely on color alone.
+func (m *modernUIRenderer) applyStatus() {
+	if m.ModernUI.Status {
+		m.status.FillColor = color.Transparent
+		m.status.StrokeColor = themeColor("StatusRead")
+		m.status.StrokeWidth = theme.TextSize() / 8
+	} else {
+		m.status.FillColor = themeColor("StatusUnread")
+		m.status.StrokeWidth = 0
 	}
 
 	m.status.Refresh()
 
-	m.image.Resource = getImageFromURL(m.ModernUI.ProfileImage)
+	for _, line := range m.message {
+		line.TextStyle.Bold = !m.ModernUI.Status
+		line.Refresh()
+	}
+}
+
+func (m *modernUIRenderer) Refresh() {
+	m.applySizes()
+	m.applyStatus()
+
+	m.check.SetChecked(m.ModernUI.Selected)
+
+	if m.ModernUI.Status {
+		m.readBtn.Hide()
+	} else {
+		m.readBtn.Show()
+	}
+
+	profileImage := m.ModernUI.profileImage()
+	m.image.Resource = avatarCache.Get(profileImage, fyne.CurrentApp().Settings().Theme().Icon("GitHub"), func(image fyne.Resource) {
+		runOnUI(func() {
+			if m.ModernUI.profileImage() != profileImage {
+				return
+			}
+
+			m.image.Resource = image
+			m.image.Refresh()
+		})
+	})
 	m.image.Refresh()
 
-	m.name.Text = trimmedText(m.ModernUI.ProfileName, m.name.Size().Width, &fyne.TextStyle{Bold: true})
+	m.typeIcon.Resource = subjectTypeIcon(m.ModernUI.SubjectType)
+
+	if m.typeIcon.Resource == nil {
+		m.typeBadge.Hide()
+		m.typeIcon.Hide()
+	} else {
+		m.typeBadge.FillColor = theme.BackgroundColor()
+		m.typeBadge.Show()
+		m.typeBadge.Refresh()
+		m.typeIcon.Show()
+		m.typeIcon.Refresh()
+	}
+
+	m.name.Text = truncateText(m.ModernUI.ProfileName, m.name.Size().Width, m.name.TextSize, m.name.TextStyle)
+	m.name.Color = theme.ForegroundColor()
 	m.name.Refresh()
 
 	m.ntype.Text = (m.ModernUI.Type)
+	m.ntype.Color = themeColor("NType")
 	m.ntype.Refresh()
 
-	m.message.Text = trimmedText(m.ModernUI.Message, m.message.Size().Width, &fyne.TextStyle{})
-	m.message.Refresh()
+	m.state.Text = m.ModernUI.State
+	m.state.Color = stateColor(m.ModernUI.StateName)
+	m.state.Refresh()
+
+	for _, line := range m.message {
+		line.Color = theme.ForegroundColor()
+	}
+
+	m.layoutMessage(m.message[0].Size().Width)
+
+	m.details.Text = truncateText(m.ModernUI.Details, m.details.Size().Width, m.details.TextSize, m.details.TextStyle)
+	m.details.Color = themeColor("NType")
+	m.details.Refresh()
 
 	m.time.Text = (convertTimeToTimeAgo(m.ModernUI.Time))
+	m.time.Color = themeColor("Time")
 	m.time.Refresh()
 }
 
 func (m *modernUIRenderer) Resize(size fyne.Size) {
 	padding := 2 * theme.Padding()
 
-	statusPosX := padding
+	checkPosX := padding / 2.0
+	checkPosY := size.Height/2.0 - m.check.Size().Height/2.0
+
+	m.check.Move(fyne.NewPos(checkPosX, checkPosY))
+
+	statusPosX := float32(m.check.Position().X + m.check.Size().Width)
 	statusPosY := size.Height/2.0 - m.status.Size().Height/2.0
 
 	m.status.Move(fyne.NewPos(statusPosX, statusPosY))
@@ -244,6 +474,15 @@ func (m *modernUIRenderer) Resize(size fyne.Size) {
 
 	m.image.Move(fyne.NewPos(imagePosX, imagePosY))
 
+	typeBadgePosX := float32(imagePosX + m.image.Size().Width - m.typeBadge.Size().Width + padding/4.0)
+	typeBadgePosY := float32(imagePosY + m.image.Size().Height - m.typeBadge.Size().Height + padding/4.0)
+
+	m.typeBadge.Move(fyne.NewPos(typeBadgePosX, typeBadgePosY))
+	m.typeIcon.Move(fyne.NewPos(
+		typeBadgePosX+(m.typeBadge.Size().Width-m.typeIcon.Size().Width)/2.0,
+		typeBadgePosY+(m.typeBadge.Size().Height-m.typeIcon.Size().Height)/2.0,
+	))
+
 	readBtnPosX := float32(size.Width - m.readBtn.Size().Width - padding)
 	readBtnPosY := float32(m.image.Position().Y + m.image.Size().Height/2.0 - m.readBtn.Size().Height/2.0)
 
@@ -264,57 +503,68 @@ func (m *modernUIRenderer) Resize(size fyne.Size) {
 	ntypePosY := float32(m.image.Position().Y + m.image.Size().Height/2.0)
 
 	m.ntype.Move(fyne.NewPos(ntypePosX, ntypePosY))
-	m.ntype.Resize(fyne.NewSize(m.openBtn.Position().X-ntypePosX-padding, m.ntype.MinSize().Height))
-	m.name.Text = trimmedText(m.ModernUI.ProfileName, m.name.Size().Width, &fyne.TextStyle{Bold: true})
+	m.ntype.Resize(m.ntype.MinSize())
+
+	statePosX := float32(m.ntype.Position().X + m.ntype.Size().Width + padding/2.0)
+
+	m.state.Move(fyne.NewPos(statePosX, ntypePosY))
+	m.state.Resize(m.state.MinSize())
+	m.name.Text = truncateText(m.ModernUI.ProfileName, m.name.Size().Width, m.name.TextSize, m.name.TextStyle)
 	m.name.Refresh()
 
 	messagePosX := float32(m.image.Position().X)
 	messagePosY := float32(m.image.Position().Y + m.image.Size().Height + padding)
 
-	m.message.Move(fyne.NewPos(messagePosX, messagePosY))
-	m.message.Resize(fyne.NewSize(size.Width-messagePosX-padding, m.message.MinSize().Height))
-	m.message.Text = trimmedText(m.ModernUI.Message, m.message.Size().Width, &fyne.TextStyle{})
-	m.message.Refresh()
+	lineHeight := m.message[0].MinSize().Height
 
-	timePosX := float32(m.message.Position().X)
-	timePosY := float32(m.message.Position().Y + m.message.Size().Height + padding/2.0)
+	for i, line := range m.message {
+		line.Move(fyne.NewPos(messagePosX, messagePosY+float32(i)*lineHeight))
+		line.Resize(fyne.NewSize(size.Width-messagePosX-padding, lineHeight))
+	}
 
-	m.time.Move(fyne.NewPos(timePosX, timePosY))
-	m.time.Resize(fyne.NewSize(size.Width-timePosX-padding, m.time.MinSize().Height))
-}
+	m.layoutMessage(size.Width - messagePosX - padding)
 
-func trimmedText(text string, width float32, textStyle *fyne.TextStyle) string {
-	textLen := len(text)
-	textSize := fyne.MeasureText(text, theme.TextSize(), *textStyle)
+	detailsPosX := messagePosX
+	detailsPosY := messagePosY + float32(m.messageLines())*lineHeight
 
-	if textSize.Width > width {
-		sizePerChar := textSize.Width / float32(textLen)
-		charsThatFit := int(width/sizePerChar) - 1
+	m.details.Move(fyne.NewPos(detailsPosX, detailsPosY))
+	m.details.Resize(fyne.NewSize(size.Width-detailsPosX-padding, m.details.MinSize().Height))
+	m.details.Text = truncateText(m.ModernUI.Details, m.details.Size().Width, m.details.TextSize, m.details.TextStyle)
+	m.details.Refresh()
 
-		if charsThatFit < 0 {
-			charsThatFit = 0
-		}
+	timePosX := float32(m.details.Position().X)
+	timePosY := float32(m.details.Position().Y + m.details.Size().Height + padding/2.0)
 
-		return text[:charsThatFit] + "..."
-	}
-
-	return text
+	m.time.Move(fyne.NewPos(timePosX, timePosY))
+	m.time.Resize(fyne.NewSize(size.Width-timePosX-padding, m.time.MinSize().Height))
 }
 
-func getImageFromURL(url string) fyne.Resource {
-	if imageCache[url] != nil {
-		return imageCache[url]
+func subjectTypeIcon(subjectType string) fyne.Resource {
+	switch subjectType {
+	case "PullRequest", "Issue", "Release", "Discussion", "Commit":
+		return fyne.CurrentApp().Settings().Theme().Icon(fyne.ThemeIconName(subjectType))
 	}
 
-	image, err := fyne.LoadResourceFromURLString(url)
+	return nil
+}
 
-	if err != nil {
-		return fyne.CurrentApp().Settings().Theme().Icon("GitHub")
+func stateColor(state string) color.Color {
+	switch state {
+	case "open":
+		return themeColor("StateOpen")
+	case "merged":
+		return themeColor("StateMerged")
+	case "closed":
+		return themeColor("StateClosed")
+	case "draft":
+		return themeColor("StateDraft")
+	case "published":
+		return themeColor("StatePublished")
+	case "prerelease":
+		return themeColor("StatePrerelease")
 	}
 
-	imageCache[url] = image
-
-	return imageCache[url]
+	return theme.ForegroundColor()
 }
 
 func convertTimeToTimeAgo(t time.Time) string {
@@ -322,20 +572,20 @@ func convertTimeToTimeAgo(t time.Time) string {
 	diff := now.Sub(t)
 
 	if diff < time.Minute {
-		return "Just now"
+		return tr("Just now")
 	}
 
 	if diff < time.Hour {
-		return fmt.Sprintf("%d minutes ago", int(diff.Minutes()))
+		return trn("%d minute ago", "%d minutes ago", int(diff.Minutes()))
 	}
 
 	if diff < time.Hour*24 {
-		return fmt.Sprintf("%d hours ago", int(diff.Hours()))
+		return trn("%d hour ago", "%d hours ago", int(diff.Hours()))
 	}
 
 	if diff < time.Hour*24*7 {
-		return fmt.Sprintf("%d days ago", int(diff.Hours()/24))
+		return trn("%d day ago", "%d days ago", int(diff.Hours()/24))
 	}
 
-	return t.Format("02 Jan 2006")
+	return formatDate(t)
 }
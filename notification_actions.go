@@ -0,0 +1,20 @@
+package main
+
+import (
+	"github.com/google/go-github/v55/github"
+)
+
+// The single-notification actions are undoable: they take effect locally
+// at once and reach GitHub after UNDO_DELAY.
+
+func readNotificationAction(notification *github.Notification) {
+	queueAction(ActionRead, notification)
+}
+
+func doneNotificationAction(notification *github.Notification) {
+	queueAction(ActionDone, notification)
+}
+
+func unsubscribeNotificationAction(notification *github.Notification) {
+	queueAction(ActionUnsubscribe, notification)
+}
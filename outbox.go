@@ -0,0 +1,345 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2/dialog"
+	"github.com/google/go-github/v55/github"
+)
+
+const OUTBOX_TASK = "actionOutbox"
+const OUTBOX_RETRY_MIN time.Duration = time.Second * 10
+const OUTBOX_RETRY_MAX time.Duration = time.Minute * 10
+const OUTBOX_FLUSH_TIMEOUT time.Duration = time.Second * 10
+
+// OutboxEntry is an action that is shown locally but not yet confirmed by
+// GitHub. The outbox is persisted so actions taken offline survive a restart.
+// Offline tells that the last attempt could not reach GitHub.
+type OutboxEntry struct {
+	Kind         ActionKind           `json:"kind"`
+	Notification *github.Notification `json:"notification"`
+	QueuedAt     time.Time            `json:"queued_at"`
+	Attempts     int                  `json:"attempts"`
+	NextAttempt  time.Time            `json:"next_attempt"`
+	LastError    string               `json:"last_error,omitempty"`
+	Offline      bool                 `json:"offline,omitempty"`
+}
+
+var outbox = struct {
+	sync.Mutex
+	entries []*OutboxEntry
+	// sending is held while sendOutbox runs, so the loop and a flush do not
+	// send the same entries twice
+	sending chan struct{}
+}{
+	sending: make(chan struct{}, 1),
+}
+
+func loadOutbox() {
+	outboxJSON := notifierApp.Preferences().String("action_outbox")
+
+	if outboxJSON == "" {
+		return
+	}
+
+	var entries []*OutboxEntry
+
+	if err := json.Unmarshal([]byte(outboxJSON), &entries); err != nil {
+		slog.Warn("Invalid action outbox", "err", err)
+		return
+	}
+
+	var known []*OutboxEntry
+
+	for _, entry := range entries {
+		if actionFunc(entry.Kind) == nil || entry.Notification == nil {
+			slog.Warn("Dropping unknown queued action", "action", entry.Kind)
+			continue
+		}
+
+		known = append(known, entry)
+	}
+
+	outbox.Lock()
+	outbox.entries = known
+	outbox.Unlock()
+}
+
+// saveOutbox must be called with the outbox locked.
+func saveOutbox() {
+	outboxJSON, err := json.Marshal(outbox.entries)
+
+	if err != nil {
+		slog.Error("Action outbox not saved", "err", err)
+		return
+	}
+
+	notifierApp.Preferences().SetString("action_outbox", string(outboxJSON))
+}
+
+func startOutbox() {
+	taskSupervisor.Start(OUTBOX_TASK, RestartOnFailure, outboxLoop)
+}
+
+func enqueueOutbox(kind ActionKind, notification *github.Notification) {
+	outbox.Lock()
+	outbox.entries = append(outbox.entries, &OutboxEntry{
+		Kind:         kind,
+		Notification: notification,
+		QueuedAt:     time.Now(),
+		NextAttempt:  time.Now(),
+	})
+	saveOutbox()
+	outbox.Unlock()
+
+	taskSupervisor.Trigger(OUTBOX_TASK)
+}
+
+func outboxLoop(ctx context.Context, trigger <-chan struct{}) error {
+	slog.Info("Action outbox loop started")
+
+	for {
+		sendOutbox(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-trigger:
+		case <-time.After(nextOutboxAttempt()):
+		}
+	}
+}
+
+// sendOutbox sends the entries that are due, in the order they were taken.
+// A network failure postpones the rest too, since they would fail the same.
+// It gives up when ctx is done, also while waiting for another send.
+func sendOutbox(ctx context.Context) {
+	select {
+	case outbox.sending <- struct{}{}:
+	case <-ctx.Done():
+		return
+	}
+
+	defer func() { <-outbox.sending }()
+
+	outbox.Lock()
+	var due []*OutboxEntry
+	for _, entry := range outbox.entries {
+		if !entry.NextAttempt.After(time.Now()) {
+			due = append(due, entry)
+		}
+	}
+	outbox.Unlock()
+
+	sent := false
+
+	for _, entry := range due {
+		if ctx.Err() != nil {
+			return
+		}
+
+		ok, err := actionFunc(entry.Kind)(ctx, entry.Notification)
+
+		outbox.Lock()
+
+		if ok || isPermanentError(err) {
+			removeOutboxEntry(entry)
+		} else {
+			entry.Attempts++
+			entry.LastError = err.Error()
+			entry.Offline = isConnectionError(err)
+			postponeOutbox(due, entry.Attempts)
+		}
+
+		saveOutbox()
+		outbox.Unlock()
+
+		if ok {
+			sent = true
+			continue
+		}
+
+		if isPermanentError(err) {
+			entry := entry
+
+			runOnUI(func() {
+				revertActionLocally(entry.Kind, entry.Notification)
+				dialog.ShowError(fmt.Errorf("%s: %w", tr("%s failed", actionMessage(entry.Kind)), err), window)
+			})
+			continue
+		}
+
+		slog.Warn("Queued action failed", "action", entry.Kind, "id", entry.Notification.GetID(), "retry_in", outboxBackoff(entry.Attempts), "err", err)
+		break
+	}
+
+	if sent {
+		refreshNotifications()
+	}
+}
+
+// postponeOutbox must be called with the outbox locked.
+func postponeOutbox(entries []*OutboxEntry, attempts int) {
+	next := time.Now().Add(outboxBackoff(attempts))
+
+	for _, entry := range entries {
+		if entry.NextAttempt.Before(next) {
+			entry.NextAttempt = next
+		}
+	}
+}
+
+// removeOutboxEntry must be called with the outbox locked.
+func removeOutboxEntry(entry *OutboxEntry) {
+	for i, e := range outbox.entries {
+		if e == entry {
+			outbox.entries = append(outbox.entries[:i], outbox.entries[i+1:]...)
+			return
+		}
+	}
+}
+
+func outboxBackoff(attempts int) time.Duration {
+	backoff := OUTBOX_RETRY_MIN
+
+	for i := 1; i < attempts && backoff < OUTBOX_RETRY_MAX; i++ {
+		backoff *= 2
+	}
+
+	if backoff > OUTBOX_RETRY_MAX {
+		backoff = OUTBOX_RETRY_MAX
+	}
+
+	return backoff
+}
+
+func nextOutboxAttempt() time.Duration {
+	outbox.Lock()
+	defer outbox.Unlock()
+
+	wait := OUTBOX_RETRY_MAX
+
+	for _, entry := range outbox.entries {
+		if until := time.Until(entry.NextAttempt); until < wait {
+			wait = until
+		}
+	}
+
+	if wait < 0 {
+		wait = 0
+	}
+
+	return wait
+}
+
+// isPermanentError tells failures worth retrying, like being offline, apart
+// from ones GitHub will keep refusing, like a thread that no longer exists.
+func isPermanentError(err error) bool {
+	var errorResponse *github.ErrorResponse
+
+	if !errors.As(err, &errorResponse) || errorResponse.Response == nil {
+		return false
+	}
+
+	switch status := errorResponse.Response.StatusCode; status {
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests:
+		return false
+	default:
+		return status >= 400 && status < 500
+	}
+}
+
+// isConnectionError tells failures to reach GitHub apart from answers it
+// gave, like a rate limit.
+func isConnectionError(err error) bool {
+	var netErr net.Error
+
+	return errors.As(err, &netErr)
+}
+
+// reconcileOutbox runs after every successful fetch. Entries the fetch shows
+// as already applied, or whose thread has new activity since the action was
+// taken, are dropped. Ones that failed for being offline are retried right
+// away, since we are online again; the others keep their backoff.
+func reconcileOutbox(notifications []*github.Notification) {
+	fetched := make(map[string]*github.Notification, len(notifications))
+
+	for _, notification := range notifications {
+		fetched[notification.GetID()] = notification
+	}
+
+	outbox.Lock()
+
+	if len(outbox.entries) == 0 {
+		outbox.Unlock()
+		return
+	}
+
+	var entries []*OutboxEntry
+
+	for _, entry := range outbox.entries {
+		notification, ok := fetched[entry.Notification.GetID()]
+
+		if ok && entry.Kind != ActionUnsubscribe && notification.GetUpdatedAt().Time.After(entry.Notification.GetUpdatedAt().Time) {
+			slog.Info("Dropping queued action, the thread has new activity", "action", entry.Kind, "id", entry.Notification.GetID())
+			continue
+		}
+
+		if ok && entry.Kind == ActionRead && !notification.GetUnread() {
+			continue
+		}
+
+		if entry.Offline {
+			entry.NextAttempt = time.Now()
+		}
+
+		entries = append(entries, entry)
+	}
+
+	outbox.entries = entries
+	saveOutbox()
+	outbox.Unlock()
+
+	taskSupervisor.Trigger(OUTBOX_TASK)
+}
+
+func outboxKinds(kinds map[string]ActionKind) {
+	outbox.Lock()
+	defer outbox.Unlock()
+
+	for _, entry := range outbox.entries {
+		addActionKind(kinds, entry.Notification.GetID(), entry.Kind)
+	}
+}
+
+func outboxText() string {
+	outbox.Lock()
+	defer outbox.Unlock()
+
+	if len(outbox.entries) == 0 {
+		return tr("No actions waiting to be sent")
+	}
+
+	text := ""
+
+	for _, entry := range outbox.entries {
+		text += fmt.Sprintf("%s  %s  %s  %s",
+			entry.QueuedAt.Format(time.TimeOnly), actionMessage(entry.Kind), entry.Notification.GetSubject().GetTitle(), trn("%d attempt", "%d attempts", entry.Attempts))
+
+		if entry.LastError != "" {
+			text += "  " + entry.LastError
+		}
+
+		text += "\n"
+	}
+
+	return text
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v55/github"
+)
+
+func queueTestOutbox(fake *fakeGitHub) {
+	fake.Lock()
+	notifications := fake.notifications
+	fake.Unlock()
+
+	outbox.Lock()
+	outbox.entries = nil
+	for _, notification := range notifications {
+		outbox.entries = append(outbox.entries, &OutboxEntry{
+			Kind:         ActionRead,
+			Notification: notification,
+			QueuedAt:     time.Now(),
+			NextAttempt:  time.Now(),
+		})
+	}
+	outbox.Unlock()
+}
+
+func TestSendOutboxSendsEachEntryOnce(t *testing.T) {
+	fake := useFakeGitHub(t, 5)
+	fake.Lock()
+	fake.threadDelay = 10 * time.Millisecond
+	fake.Unlock()
+
+	queueTestOutbox(fake)
+
+	var wg sync.WaitGroup
+
+	// the outbox loop and a flush at the same time
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			sendOutbox(context.Background())
+		}()
+	}
+
+	wg.Wait()
+
+	for i := 1; i <= 5; i++ {
+		path := "PATCH /notifications/threads/" + string(rune('0'+i))
+
+		if count := fake.requestCount(path); count != 1 {
+			t.Errorf("%s sent %d times", path, count)
+		}
+	}
+}
+
+func TestSendOutboxStopsAtDeadline(t *testing.T) {
+	fake := useFakeGitHub(t, 3)
+	fake.Lock()
+	fake.threadDelay = time.Minute
+	fake.Unlock()
+
+	queueTestOutbox(fake)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	started := time.Now()
+	sendOutbox(ctx)
+
+	if elapsed := time.Since(started); elapsed > 2*time.Second {
+		t.Errorf("sendOutbox took %s past its deadline", elapsed)
+	}
+
+	outbox.Lock()
+	left := len(outbox.entries)
+	outbox.entries = nil
+	outbox.Unlock()
+
+	if left != 3 {
+		t.Errorf("%d entries kept for the next start, want 3", left)
+	}
+}
+
+func TestReconcileOutboxKeepsBackoff(t *testing.T) {
+	fake := useFakeGitHub(t, 2)
+	later := time.Now().Add(time.Hour)
+
+	offline := &OutboxEntry{Kind: ActionDone, Notification: fake.notification(1), NextAttempt: later, Offline: true}
+	limited := &OutboxEntry{Kind: ActionDone, Notification: fake.notification(2), NextAttempt: later}
+
+	outbox.Lock()
+	outbox.entries = []*OutboxEntry{offline, limited}
+	outbox.Unlock()
+
+	defer func() {
+		outbox.Lock()
+		outbox.entries = nil
+		outbox.Unlock()
+	}()
+
+	reconcileOutbox([]*github.Notification{fake.notification(1), fake.notification(2)})
+
+	outbox.Lock()
+	defer outbox.Unlock()
+
+	if offline.NextAttempt.After(time.Now()) {
+		t.Error("entry that failed offline not retried right away")
+	}
+
+	if !limited.NextAttempt.Equal(later) {
+		t.Errorf("backoff of a rate limited entry reset to %s", limited.NextAttempt)
+	}
+}
+
+func TestIsConnectionError(t *testing.T) {
+	tests := map[string]struct {
+		err  error
+		want bool
+	}{
+		"refused":    {&url.Error{Op: "Get", URL: "https://api.github.com", Err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}}, true},
+		"forbidden":  {&github.ErrorResponse{Response: &http.Response{StatusCode: http.StatusForbidden}}, false},
+		"rate limit": {&github.RateLimitError{Response: &http.Response{StatusCode: http.StatusForbidden}}, false},
+		"other":      {errors.New("boom"), false},
+	}
+
+	for name, test := range tests {
+		if got := isConnectionError(test.err); got != test.want {
+			t.Errorf("%s: isConnectionError = %t, want %t", name, got, test.want)
+		}
+	}
+}
+
+func TestLoadOutboxDropsUnknownKinds(t *testing.T) {
+	fake := useFakeGitHub(t, 1)
+
+	entries, err := json.Marshal([]*OutboxEntry{
+		{Kind: ActionRead, Notification: fake.notification(1)},
+		{Kind: "archive", Notification: fake.notification(1)},
+		{Kind: ActionDone},
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	notifierApp.Preferences().SetString("action_outbox", string(entries))
+	defer notifierApp.Preferences().RemoveValue("action_outbox")
+
+	loadOutbox()
+
+	outbox.Lock()
+	loaded := outbox.entries
+	outbox.entries = nil
+	outbox.Unlock()
+
+	if len(loaded) != 1 || loaded[0].Kind != ActionRead {
+		t.Errorf("loaded %d entries, want only the read one", len(loaded))
+	}
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const MIN_REPEAT_TIME time.Duration = time.Second * 10
+const ACTIVE_PERIOD time.Duration = time.Minute * 5
+const IDLE_PERIOD time.Duration = time.Minute * 30
+
+// pollState tracks what adaptive polling bases its interval on. The power
+// and lock probes ask the system, and are replaced in tests.
+var pollState = struct {
+	mutex              sync.Mutex
+	windowFocused      bool
+	lastActivity       time.Time
+	serverPollInterval time.Duration
+	onBattery          func() bool
+	screenLocked       func() bool
+}{
+	onBattery:    isOnBattery,
+	screenLocked: isScreenLocked,
+}
+
+func pollInterval() time.Duration {
+	interval := time.Duration(currentConfig().Polling.Interval) * time.Second
+
+	if interval < MIN_REPEAT_TIME {
+		return MIN_REPEAT_TIME
+	}
+
+	return interval
+}
+
+func lookbackDays() int {
+	days := currentConfig().Polling.LookbackDays
+
+	if days < 1 {
+		return 1
+	}
+
+	return days
+}
+
+func isAdaptivePolling() bool {
+	return currentConfig().Polling.Adaptive
+}
+
+func watchWindowFocus() {
+	notifierApp.Lifecycle().SetOnEnteredForeground(func() {
+		setWindowFocused(true)
+	})
+	notifierApp.Lifecycle().SetOnExitedForeground(func() {
+		setWindowFocused(false)
+
+		// the compact window closes like a popover when clicking elsewhere
+		if compactWindow && isWindowVisible() {
+			runOnUI(hideWindow)
+		}
+	})
+}
+
+func setWindowFocused(focused bool) {
+	pollState.mutex.Lock()
+	defer pollState.mutex.Unlock()
+
+	pollState.windowFocused = focused
+
+	if focused {
+		pollState.lastActivity = time.Now()
+	}
+}
+
+func recordActivity() {
+	pollState.mutex.Lock()
+	defer pollState.mutex.Unlock()
+
+	pollState.lastActivity = time.Now()
+}
+
+func recordServerPollInterval(header http.Header) {
+	seconds, err := strconv.Atoi(header.Get("X-Poll-Interval"))
+
+	if err != nil {
+		return
+	}
+
+	pollState.mutex.Lock()
+	defer pollState.mutex.Unlock()
+
+	pollState.serverPollInterval = time.Duration(seconds) * time.Second
+}
+
+// nextPollInterval returns how long to wait before the next poll. In
+// adaptive mode it polls faster while the user is around and slower when
+// idle, on battery or locked, but never faster than the server allows.
+func nextPollInterval() time.Duration {
+	interval := pollInterval()
+
+	pollState.mutex.Lock()
+	focused := pollState.windowFocused
+	sinceActivity := time.Since(pollState.lastActivity)
+	serverPollInterval := pollState.serverPollInterval
+	onBattery := pollState.onBattery
+	screenLocked := pollState.screenLocked
+	pollState.mutex.Unlock()
+
+	if isAdaptivePolling() {
+		switch {
+		case screenLocked():
+			interval *= 4
+		case onBattery():
+			interval *= 2
+		case focused || sinceActivity < ACTIVE_PERIOD:
+			interval /= 2
+		case sinceActivity > IDLE_PERIOD:
+			interval *= 2
+		}
+
+		if interval < MIN_REPEAT_TIME {
+			interval = MIN_REPEAT_TIME
+		}
+	}
+
+	if interval < serverPollInterval {
+		interval = serverPollInterval
+	}
+
+	return interval
+}
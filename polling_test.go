@@ -0,0 +1,66 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextPollInterval(t *testing.T) {
+	useFakeGitHub(t, 0)
+
+	tests := []struct {
+		name               string
+		interval           int
+		notAdaptive        bool
+		focused            bool
+		sinceActivity      time.Duration
+		onBattery          bool
+		screenLocked       bool
+		serverPollInterval time.Duration
+		want               time.Duration
+	}{
+		{name: "focused", interval: 60, focused: true, sinceActivity: time.Hour, want: 30 * time.Second},
+		{name: "recently active", interval: 60, sinceActivity: time.Minute, want: 30 * time.Second},
+		{name: "around", interval: 60, sinceActivity: 10 * time.Minute, want: 60 * time.Second},
+		{name: "idle", interval: 60, sinceActivity: time.Hour, want: 120 * time.Second},
+		{name: "battery", interval: 60, focused: true, onBattery: true, want: 120 * time.Second},
+		{name: "locked", interval: 60, onBattery: true, screenLocked: true, want: 240 * time.Second},
+		{name: "not adaptive", interval: 60, notAdaptive: true, screenLocked: true, want: 60 * time.Second},
+		{name: "never below the minimum", interval: 10, focused: true, want: MIN_REPEAT_TIME},
+		{name: "server minimum", interval: 60, focused: true, serverPollInterval: 90 * time.Second, want: 90 * time.Second},
+		{name: "server minimum when not adaptive", interval: 60, notAdaptive: true, serverPollInterval: 90 * time.Second, want: 90 * time.Second},
+	}
+
+	t.Cleanup(func() {
+		pollState.mutex.Lock()
+		pollState.windowFocused = false
+		pollState.lastActivity = time.Time{}
+		pollState.serverPollInterval = 0
+		pollState.onBattery = isOnBattery
+		pollState.screenLocked = isScreenLocked
+		pollState.mutex.Unlock()
+	})
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			appConfig.Lock()
+			appConfig.effective.Polling.Interval = test.interval
+			appConfig.effective.Polling.Adaptive = !test.notAdaptive
+			appConfig.Unlock()
+
+			onBattery, screenLocked := test.onBattery, test.screenLocked
+
+			pollState.mutex.Lock()
+			pollState.windowFocused = test.focused
+			pollState.lastActivity = time.Now().Add(-test.sinceActivity)
+			pollState.serverPollInterval = test.serverPollInterval
+			pollState.onBattery = func() bool { return onBattery }
+			pollState.screenLocked = func() bool { return screenLocked }
+			pollState.mutex.Unlock()
+
+			if got := nextPollInterval(); got != test.want {
+				t.Errorf("nextPollInterval() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+//go:build linux
+
+package main
+
+import (
+	"github.com/godbus/dbus/v5"
+)
+
+func isOnBattery() bool {
+	conn, err := dbus.SystemBus()
+
+	if err != nil {
+		return false
+	}
+
+	upower := conn.Object("org.freedesktop.UPower", "/org/freedesktop/UPower")
+
+	onBattery, err := upower.GetProperty("org.freedesktop.UPower.OnBattery")
+
+	if err != nil {
+		return false
+	}
+
+	value, _ := onBattery.Value().(bool)
+
+	return value
+}
+
+func isScreenLocked() bool {
+	conn, err := dbus.SystemBus()
+
+	if err != nil {
+		return false
+	}
+
+	session := conn.Object("org.freedesktop.login1", "/org/freedesktop/login1/session/auto")
+
+	lockedHint, err := session.GetProperty("org.freedesktop.login1.Session.LockedHint")
+
+	if err != nil {
+		return false
+	}
+
+	value, _ := lockedHint.Value().(bool)
+
+	return value
+}
@@ -0,0 +1,11 @@
+//go:build !linux
+
+package main
+
+func isOnBattery() bool {
+	return false
+}
+
+func isScreenLocked() bool {
+	return false
+}
@@ -0,0 +1,275 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"github.com/google/go-github/v55/github"
+)
+
+// REACTIONS maps the labels shown in the UI to GitHub reaction contents.
+var REACTIONS = []struct {
+	Label   string
+	Content string
+}{
+	{"👍", "+1"},
+	{"👎", "-1"},
+	{"😄", "laugh"},
+	{"🎉", "hooray"},
+	{"😕", "confused"},
+	{"❤️", "heart"},
+	{"🚀", "rocket"},
+	{"👀", "eyes"},
+}
+
+func subjectRepository(notification *github.Notification) (string, string, int, error) {
+	subjectType := notification.GetSubject().GetType()
+
+	if subjectType != "Issue" && subjectType != "PullRequest" {
+		return "", "", 0, errors.New(tr("cannot reply to a %s", subjectType))
+	}
+
+	number, ok := subjectNumber(notification)
+
+	if !ok {
+		return "", "", 0, errors.New(tr("notification has no issue or pull request number"))
+	}
+
+	return notification.GetRepository().GetOwner().GetLogin(), notification.GetRepository().GetName(), number, nil
+}
+
+func postComment(notification *github.Notification, body string) (bool, error) {
+	owner, repo, number, err := subjectRepository(notification)
+
+	if err != nil {
+		return false, err
+	}
+
+	ctxTimeOut, cancel := context.WithTimeout(globalCtx, time.Second*10)
+	defer cancel()
+
+	_, _, err = githubClient().Issues.CreateComment(ctxTimeOut, owner, repo, number, &github.IssueComment{Body: &body})
+
+	if err != nil {
+		slog.Warn("Failed to comment", "id", notification.GetID(), "err", err)
+		return false, err
+	}
+
+	slog.Debug("Commented", "id", notification.GetID())
+
+	return true, nil
+}
+
+func addReaction(notification *github.Notification, content string) (bool, error) {
+	owner, repo, number, err := subjectRepository(notification)
+
+	if err != nil {
+		return false, err
+	}
+
+	ctxTimeOut, cancel := context.WithTimeout(globalCtx, time.Second*10)
+	defer cancel()
+
+	_, _, err = githubClient().Reactions.CreateIssueReaction(ctxTimeOut, owner, repo, number, content)
+
+	if err != nil {
+		slog.Warn("Failed to add reaction", "id", notification.GetID(), "err", err)
+		return false, err
+	}
+
+	slog.Debug("Added reaction", "id", notification.GetID(), "reaction", content)
+
+	return true, nil
+}
+
+// submitReview submits a pull request review. event is APPROVE,
+// REQUEST_CHANGES or COMMENT.
+func submitReview(notification *github.Notification, event string, body string) (bool, error) {
+	if notification.GetSubject().GetType() != "PullRequest" {
+		return false, errors.New(tr("only pull requests can be reviewed"))
+	}
+
+	if event == "REQUEST_CHANGES" && strings.TrimSpace(body) == "" {
+		return false, errors.New(tr("requesting changes needs a comment"))
+	}
+
+	owner, repo, number, err := subjectRepository(notification)
+
+	if err != nil {
+		return false, err
+	}
+
+	ctxTimeOut, cancel := context.WithTimeout(globalCtx, time.Second*10)
+	defer cancel()
+
+	review := &github.PullRequestReviewRequest{Event: &event}
+
+	if body != "" {
+		review.Body = &body
+	}
+
+	_, _, err = githubClient().PullRequests.CreateReview(ctxTimeOut, owner, repo, number, review)
+
+	if err != nil {
+		slog.Warn("Failed to submit review", "id", notification.GetID(), "event", event, "err", err)
+		return false, err
+	}
+
+	slog.Debug("Submitted review", "id", notification.GetID(), "event", event)
+
+	return true, nil
+}
+
+// replyDrafts keeps what was typed into the comment box of each
+// notification until it is sent, so it survives the detail pane being
+// rebuilt.
+var replyDrafts = struct {
+	sync.Mutex
+	text map[string]string
+}{
+	text: make(map[string]string),
+}
+
+func replyDraft(id string) string {
+	replyDrafts.Lock()
+	defer replyDrafts.Unlock()
+
+	return replyDrafts.text[id]
+}
+
+func setReplyDraft(id string, text string) {
+	replyDrafts.Lock()
+	defer replyDrafts.Unlock()
+
+	if text == "" {
+		delete(replyDrafts.text, id)
+	} else {
+		replyDrafts.text[id] = text
+	}
+}
+
+// addReplyUI builds the comment box, reactions and, for pull requests, the
+// review buttons shown at the bottom of the detail pane. Every action asks
+// for confirmation first.
+func addReplyUI(notification *github.Notification) fyne.CanvasObject {
+	subjectType := notification.GetSubject().GetType()
+
+	if subjectType != "Issue" && subjectType != "PullRequest" {
+		return container.NewVBox()
+	}
+
+	replyEntry := widget.NewMultiLineEntry()
+	replyEntry.SetPlaceHolder(tr("Leave a comment"))
+	replyEntry.SetMinRowsVisible(3)
+	replyEntry.SetText(replyDraft(notification.GetID()))
+	replyEntry.OnChanged = func(text string) {
+		setReplyDraft(notification.GetID(), text)
+	}
+
+	commentBtn := widget.NewButton(tr("Comment"), func() {
+		body := strings.TrimSpace(replyEntry.Text)
+
+		if body == "" {
+			return
+		}
+
+		confirmAction(tr("Post comment?"), excerpt(body, COMMENT_EXCERPT_LENGTH), func() {
+			replyAction(notification, tr("Comment"), func() (bool, error) {
+				return postComment(notification, body)
+			}, func() {
+				replyEntry.SetText("")
+			})
+		})
+	})
+
+	reactions := container.NewHBox()
+
+	for _, reaction := range REACTIONS {
+		reaction := reaction
+
+		reactions.Add(widget.NewButton(reaction.Label, func() {
+			confirmAction(tr("Add reaction?"), reaction.Label, func() {
+				replyAction(notification, tr("Reaction"), func() (bool, error) {
+					return addReaction(notification, reaction.Content)
+				}, nil)
+			})
+		}))
+	}
+
+	actions := container.NewHBox(commentBtn)
+
+	if subjectType == "PullRequest" {
+		actions.Add(widget.NewButton(tr("Approve"), func() {
+			body := strings.TrimSpace(replyEntry.Text)
+
+			message := tr("Approve without a comment.")
+
+			if body != "" {
+				message = excerpt(body, COMMENT_EXCERPT_LENGTH)
+			}
+
+			confirmAction(tr("Approve pull request?"), message, func() {
+				replyAction(notification, tr("Approve"), func() (bool, error) {
+					return submitReview(notification, "APPROVE", body)
+				}, func() {
+					replyEntry.SetText("")
+				})
+			})
+		}))
+
+		actions.Add(widget.NewButton(tr("Request changes"), func() {
+			body := strings.TrimSpace(replyEntry.Text)
+
+			if body == "" {
+				dialog.ShowError(errors.New(tr("Requesting changes needs a comment.")), window)
+				return
+			}
+
+			confirmAction(tr("Request changes?"), excerpt(body, COMMENT_EXCERPT_LENGTH), func() {
+				replyAction(notification, tr("Request changes"), func() (bool, error) {
+					return submitReview(notification, "REQUEST_CHANGES", body)
+				}, func() {
+					replyEntry.SetText("")
+				})
+			})
+		}))
+	}
+
+	return container.NewVBox(replyEntry, actions, reactions)
+}
+
+func confirmAction(title string, message string, onConfirm func()) {
+	dialog.ShowConfirm(title, message, func(confirmed bool) {
+		if confirmed {
+			onConfirm()
+		}
+	}, window)
+}
+
+func replyAction(notification *github.Notification, name string, action func() (bool, error), onSuccess func()) {
+	go func() {
+		ok, err := action()
+
+		runOnUI(func() {
+			if !ok {
+				dialog.ShowError(fmt.Errorf("%s: %w", tr("%s failed", name), err), window)
+				return
+			}
+
+			if onSuccess != nil {
+				onSuccess()
+			}
+
+			refreshNotifications()
+		})
+	}()
+}
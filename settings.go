@@ -0,0 +1,569 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+)
+
+// openSettingsPanel opens the settings window, or focuses it when it is
+// already open. Closing it without saving leaves everything as it was.
+func openSettingsPanel() {
+	if w := currentSettingsWindow(); w != nil {
+		w.RequestFocus()
+		return
+	}
+
+	config := fileConfig()
+
+	settingsWindow := notifierApp.NewWindow(tr("Settings"))
+	settingsWindow.SetOnClosed(func() {
+		setSettingsWindow(nil)
+	})
+	setSettingsWindow(settingsWindow)
+
+	// accounts
+	accountNameEntry := widget.NewEntry()
+	accountNameEntry.SetText("default")
+	accountNameEntry.Validator = validateNotEmpty
+
+	githubTokenEntry := widget.NewPasswordEntry()
+	githubTokenEntry.SetPlaceHolder(tr("Enter GitHub token"))
+
+	if len(config.Accounts) != 0 {
+		accountNameEntry.SetText(config.Accounts[0].Name)
+		githubTokenEntry.SetText(config.Accounts[0].Token)
+	}
+
+	connectionLabel := widget.NewLabel("")
+	connectionLabel.Wrapping = fyne.TextWrapWord
+
+	testButton := widget.NewButton(tr("Test connection"), nil)
+	testButton.OnTapped = func() {
+		testButton.Disable()
+		connectionLabel.SetText(tr("Connecting..."))
+
+		go func() {
+			info, err := checkToken(globalCtx, githubTokenEntry.Text)
+
+			runOnUI(func() {
+				testButton.Enable()
+
+				if err != nil {
+					connectionLabel.SetText(err.Error())
+					return
+				}
+
+				connectionLabel.SetText(tokenInfoText(info))
+			})
+		}()
+	}
+
+	accountsForm := widget.NewForm(
+		widget.NewFormItem(tr("Name"), accountNameEntry),
+		widget.NewFormItem(tr("Token"), githubTokenEntry),
+	)
+
+	// polling
+	pollIntervalEntry := widget.NewEntry()
+	pollIntervalEntry.SetText(strconv.Itoa(config.Polling.Interval))
+	pollIntervalEntry.Validator = validateMinInt(int(MIN_REPEAT_TIME / time.Second))
+
+	lookbackEntry := widget.NewEntry()
+	lookbackEntry.SetText(strconv.Itoa(config.Polling.LookbackDays))
+	lookbackEntry.Validator = validateMinInt(1)
+
+	adaptiveCheck := widget.NewCheck(tr("Poll faster when active, slower when idle"), nil)
+	adaptiveCheck.SetChecked(config.Polling.Adaptive)
+
+	pollingForm := widget.NewForm(
+		widget.NewFormItem(tr("Interval (s)"), pollIntervalEntry),
+		widget.NewFormItem(tr("Lookback (days)"), lookbackEntry),
+		widget.NewFormItem(tr("Adaptive"), adaptiveCheck),
+	)
+
+	// notifications
+	dndCheck := widget.NewCheck(tr("Do not disturb"), nil)
+	dndCheck.SetChecked(config.DND.Enabled)
+
+	dndStartEntry := widget.NewEntry()
+	dndStartEntry.SetPlaceHolder("22:00")
+	dndStartEntry.SetText(config.DND.Start)
+	dndStartEntry.Validator = validateTimeOfDay
+
+	dndEndEntry := widget.NewEntry()
+	dndEndEntry.SetPlaceHolder("08:00")
+	dndEndEntry.SetText(config.DND.End)
+	dndEndEntry.Validator = validateTimeOfDay
+
+	hooksEntry := widget.NewMultiLineEntry()
+	hooksEntry.SetPlaceHolder(`[{"name": "tracker", "reasons": ["review_requested"], "url": "http://localhost:8080"}]`)
+	hooksEntry.SetText(hooksJSONText(config.Hooks))
+	hooksEntry.SetMinRowsVisible(8)
+	hooksEntry.Validator = func(text string) error {
+		_, err := parseHooks(text)
+		return err
+	}
+
+	notificationsForm := widget.NewForm(
+		widget.NewFormItem(tr("Quiet hours"), dndCheck),
+		widget.NewFormItem(tr("From"), dndStartEntry),
+		widget.NewFormItem(tr("Until"), dndEndEntry),
+		widget.NewFormItem(tr("Hooks"), hooksEntry),
+	)
+
+	// filters
+	reasonsEntry := widget.NewEntry()
+	reasonsEntry.SetPlaceHolder("review_requested, mention")
+	reasonsEntry.SetText(strings.Join(config.Filters.Reasons, ", "))
+
+	repositoriesEntry := widget.NewEntry()
+	repositoriesEntry.SetPlaceHolder("my-org/*")
+	repositoriesEntry.SetText(strings.Join(config.Filters.Repositories, ", "))
+	repositoriesEntry.Validator = validatePatterns
+
+	excludeEntry := widget.NewEntry()
+	excludeEntry.SetText(strings.Join(config.Filters.ExcludeRepositories, ", "))
+	excludeEntry.Validator = validatePatterns
+
+	filtersForm := widget.NewForm(
+		widget.NewFormItem(tr("Reasons"), reasonsEntry),
+		widget.NewFormItem(tr("Repositories"), repositoriesEntry),
+		widget.NewFormItem(tr("Exclude"), excludeEntry),
+	)
+
+	// appearance
+	languages := map[string]string{tr("System"): ""}
+	languageNames := []string{tr("System")}
+
+	for _, code := range languageCodes() {
+		name := fmt.Sprintf("%s (%s)", LANGUAGES[code].Name, code)
+		languages[name] = code
+		languageNames = append(languageNames, name)
+	}
+
+	languageSelect := widget.NewSelect(languageNames, nil)
+	languageSelect.SetSelected(tr("System"))
+
+	for name, code := range languages {
+		if language, _ := parseLocale(config.Appearance.Language); code != "" && code == language {
+			languageSelect.SetSelected(name)
+		}
+	}
+
+	themeSelect, themeValue := newOptionSelect([]string{"", "light", "dark"}, config.Appearance.Theme, themeLabel)
+
+	accents := append([]string{""}, accentNames()...)
+
+	if _, ok := ACCENT_COLORS[config.Appearance.Accent]; !ok && config.Appearance.Accent != "" {
+		accents = append(accents, config.Appearance.Accent)
+	}
+
+	accentSelect, accentValue := newOptionSelect(accents, config.Appearance.Accent, accentLabel)
+
+	textScales := []string{"100%", "125%", "150%", "175%", "200%"}
+	textScale := fmt.Sprintf("%d%%", config.Appearance.TextScale)
+
+	if config.Appearance.TextScale == 0 {
+		textScale = "100%"
+	}
+
+	if !containsString(textScales, textScale) {
+		textScales = append(textScales, textScale)
+	}
+
+	textScaleSelect := widget.NewSelect(textScales, nil)
+	textScaleSelect.SetSelected(textScale)
+
+	titleLinesSelect := widget.NewSelect([]string{"1", "2", "3"}, nil)
+	titleLinesSelect.SetSelected(strconv.Itoa(titleLines()))
+
+	highContrastCheck := widget.NewCheck(tr("High contrast"), nil)
+	highContrastCheck.SetChecked(config.Appearance.HighContrast)
+
+	showAllCheck := widget.NewCheck(tr("Show read notifications"), nil)
+	showAllCheck.SetChecked(isShowAllNotifications())
+
+	compactCheck := widget.NewCheck(tr("Compact window that hides when it loses focus"), nil)
+	compactCheck.SetChecked(config.Appearance.CompactWindow)
+
+	compactItem := widget.NewFormItem(tr("Window"), compactCheck)
+	compactItem.HintText = tr("Takes effect on restart.")
+
+	languageItem := widget.NewFormItem(tr("Language"), languageSelect)
+	languageItem.HintText = tr("Changing the language takes effect on restart.")
+
+	appearanceForm := widget.NewForm(
+		languageItem,
+		widget.NewFormItem(tr("Theme"), themeSelect),
+		widget.NewFormItem(tr("Accent"), accentSelect),
+		widget.NewFormItem(tr("Text size"), textScaleSelect),
+		widget.NewFormItem(tr("Title lines"), titleLinesSelect),
+		widget.NewFormItem(tr("Contrast"), highContrastCheck),
+		widget.NewFormItem(tr("List"), showAllCheck),
+		compactItem,
+	)
+
+	// advanced
+	keymapEntry := widget.NewMultiLineEntry()
+	keymapEntry.SetText(keymapText())
+	keymapEntry.SetMinRowsVisible(8)
+	keymapEntry.Validator = func(text string) error {
+		_, err := parseKeymap(text)
+		return err
+	}
+
+	configPathLabel := widget.NewLabel(configPath())
+	configPathLabel.Wrapping = fyne.TextWrapBreak
+
+	minimizedCheck := widget.NewCheck(tr("Start hidden in the tray"), nil)
+	minimizedCheck.SetChecked(config.Startup.Minimized)
+
+	autostartCheck := widget.NewCheck(tr("Start minimised on login"), nil)
+	autostartCheck.SetChecked(config.Startup.Autostart)
+
+	systemdCheck := widget.NewCheck(tr("Run as a systemd user service"), nil)
+	systemdCheck.SetChecked(config.Startup.Systemd)
+
+	if runtime.GOOS != "linux" {
+		systemdCheck.Hide()
+	}
+
+	autostartCheck.OnChanged = func(checked bool) {
+		if checked {
+			systemdCheck.Enable()
+		} else {
+			systemdCheck.Disable()
+		}
+	}
+	autostartCheck.OnChanged(autostartCheck.Checked)
+
+	advancedForm := widget.NewForm(
+		widget.NewFormItem(tr("Startup"), autostartCheck),
+		widget.NewFormItem("", systemdCheck),
+		widget.NewFormItem("", minimizedCheck),
+		widget.NewFormItem(tr("Keymap"), keymapEntry),
+		widget.NewFormItem(tr("Config file"), configPathLabel),
+	)
+
+	tabs := container.NewAppTabs(
+		container.NewTabItem(tr("Accounts"), container.NewVBox(
+			accountsForm,
+			container.NewHBox(testButton),
+			connectionLabel,
+		)),
+		container.NewTabItem(tr("Polling"), pollingForm),
+		container.NewTabItem(tr("Notifications"), notificationsForm),
+		container.NewTabItem(tr("Filters"), filtersForm),
+		container.NewTabItem(tr("Appearance"), appearanceForm),
+		container.NewTabItem(tr("Advanced"), advancedForm),
+	)
+
+	forms := []*widget.Form{accountsForm, pollingForm, notificationsForm, filtersForm, appearanceForm, advancedForm}
+
+	saveButton := widget.NewButton(tr("Save"), nil)
+	saveButton.Importance = widget.HighImportance
+
+	save := func(config Config, showAll bool) {
+		if err := saveConfig(config); err != nil {
+			dialog.ShowError(err, settingsWindow)
+			return
+		}
+
+		if showAll != isShowAllNotifications() {
+			setShowAllNotifications(showAll)
+		}
+
+		settingsWindow.Close()
+	}
+
+	// a config file that could not be read is only replaced when confirmed
+	commit := func(config Config, showAll bool) {
+		fileErr := configFileError()
+
+		if fileErr == nil {
+			save(config, showAll)
+			return
+		}
+
+		dialog.ShowConfirm(tr("Replace the config file?"),
+			tr("%s could not be read:\n%s\n\nSaving replaces it with these settings.", configPath(), fileErr),
+			func(ok bool) {
+				if ok {
+					save(config, showAll)
+				}
+			},
+			settingsWindow)
+	}
+
+	saveButton.OnTapped = func() {
+		for i, form := range forms {
+			if err := form.Validate(); err != nil {
+				tabs.SelectIndex(i)
+				dialog.ShowError(err, settingsWindow)
+				return
+			}
+		}
+
+		updated := config
+		previousToken := ""
+
+		if len(updated.Accounts) != 0 {
+			previousToken = updated.Accounts[0].Token
+		}
+
+		updated.Accounts = append([]AccountConfig(nil), updated.Accounts...)
+
+		if len(updated.Accounts) == 0 {
+			updated.Accounts = []AccountConfig{{}}
+		}
+
+		updated.Accounts[0].Name = strings.TrimSpace(accountNameEntry.Text)
+		updated.Accounts[0].Token = strings.TrimSpace(githubTokenEntry.Text)
+
+		updated.Polling.Interval, _ = strconv.Atoi(pollIntervalEntry.Text)
+		updated.Polling.LookbackDays, _ = strconv.Atoi(lookbackEntry.Text)
+		updated.Polling.Adaptive = adaptiveCheck.Checked
+
+		updated.DND = DNDConfig{
+			Enabled: dndCheck.Checked,
+			Start:   strings.TrimSpace(dndStartEntry.Text),
+			End:     strings.TrimSpace(dndEndEntry.Text),
+		}
+		updated.Hooks, _ = parseHooks(hooksEntry.Text)
+
+		updated.Filters = FilterConfig{
+			Reasons:             splitList(reasonsEntry.Text),
+			Repositories:        splitList(repositoriesEntry.Text),
+			ExcludeRepositories: splitList(excludeEntry.Text),
+		}
+
+		updated.Appearance = AppearanceConfig{
+			Language:      config.Appearance.Language,
+			Theme:         themeValue(),
+			Accent:        accentValue(),
+			HighContrast:  highContrastCheck.Checked,
+			CompactWindow: compactCheck.Checked,
+		}
+
+		updated.Appearance.TextScale, _ = strconv.Atoi(strings.TrimSuffix(textScaleSelect.Selected, "%"))
+
+		updated.Appearance.TitleLines, _ = strconv.Atoi(titleLinesSelect.Selected)
+
+		if updated.Appearance.TitleLines == 1 {
+			updated.Appearance.TitleLines = 0
+		}
+
+		if updated.Appearance.TextScale == 100 {
+			updated.Appearance.TextScale = 0
+		}
+
+		// a region from the config file is kept while the language stays
+		if language, _ := parseLocale(updated.Appearance.Language); language != languages[languageSelect.Selected] {
+			updated.Appearance.Language = languages[languageSelect.Selected]
+		}
+
+		updated.Startup = StartupConfig{
+			Autostart: autostartCheck.Checked,
+			Systemd:   systemdCheck.Checked,
+			Minimized: minimizedCheck.Checked,
+		}
+
+		actionKeys, _ := parseKeymap(keymapEntry.Text)
+		updated.Keymap = keymapOverrides(actionKeys)
+
+		showAll := showAllCheck.Checked
+		token := updated.Accounts[0].Token
+
+		// a new token is checked with GitHub before it replaces the old one
+		if token == "" || token == previousToken {
+			commit(updated, showAll)
+			return
+		}
+
+		saveButton.Disable()
+		connectionLabel.SetText(tr("Checking token..."))
+
+		go func() {
+			info, err := checkToken(globalCtx, token)
+
+			runOnUI(func() {
+				// closed while checking, which is a cancel
+				if currentSettingsWindow() != settingsWindow {
+					return
+				}
+
+				saveButton.Enable()
+
+				var tokenErr *TokenError
+
+				if errors.As(err, &tokenErr) {
+					tabs.SelectIndex(0)
+					connectionLabel.SetText(err.Error())
+					dialog.ShowError(err, settingsWindow)
+					return
+				}
+
+				if err != nil {
+					connectionLabel.SetText(err.Error())
+					dialog.ShowConfirm(tr("Could not check the token"),
+						tr("%s\n\nSave it anyway?", err),
+						func(ok bool) {
+							if ok {
+								commit(updated, showAll)
+							}
+						},
+						settingsWindow)
+					return
+				}
+
+				commit(updated, showAll)
+
+				if len(info.Warnings) != 0 {
+					dialog.ShowInformation(tr("GitHub token"), strings.Join(info.Warnings, "\n"), window)
+				}
+			})
+		}()
+	}
+
+	cancelButton := widget.NewButton(tr("Cancel"), func() {
+		settingsWindow.Close()
+	})
+
+	settingsWindow.SetContent(container.NewBorder(
+		nil,
+		container.NewHBox(layout.NewSpacer(), cancelButton, saveButton),
+		nil,
+		nil,
+		tabs,
+	))
+	settingsWindow.Resize(fyne.NewSize(520, 480))
+	settingsWindow.Show()
+}
+
+// newOptionSelect shows translated labels for config values and returns the
+// value of the selected label.
+func newOptionSelect(values []string, selected string, label func(string) string) (*widget.Select, func() string) {
+	labels := make([]string, len(values))
+	byLabel := make(map[string]string, len(values))
+
+	for i, value := range values {
+		labels[i] = label(value)
+		byLabel[labels[i]] = value
+	}
+
+	optionSelect := widget.NewSelect(labels, nil)
+	optionSelect.SetSelected(label(selected))
+
+	return optionSelect, func() string {
+		return byLabel[optionSelect.Selected]
+	}
+}
+
+func themeLabel(value string) string {
+	switch value {
+	case "", "system":
+		return tr("System")
+	case "light":
+		return tr("Light")
+	case "dark":
+		return tr("Dark")
+	}
+
+	return value
+}
+
+func accentLabel(value string) string {
+	switch value {
+	case "":
+		return tr("Default")
+	case "blue":
+		return tr("Blue")
+	case "green":
+		return tr("Green")
+	case "orange":
+		return tr("Orange")
+	case "pink":
+		return tr("Pink")
+	case "purple":
+		return tr("Purple")
+	case "red":
+		return tr("Red")
+	}
+
+	return value
+}
+
+func containsString(items []string, item string) bool {
+	for _, i := range items {
+		if i == item {
+			return true
+		}
+	}
+
+	return false
+}
+
+func splitList(text string) []string {
+	var items []string
+
+	for _, item := range strings.Split(text, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+func validateNotEmpty(text string) error {
+	if strings.TrimSpace(text) == "" {
+		return errors.New(tr("must not be empty"))
+	}
+
+	return nil
+}
+
+func validateMinInt(min int) func(string) error {
+	return func(text string) error {
+		value, err := strconv.Atoi(text)
+
+		if err != nil || value < min {
+			return errors.New(tr("must be a number of at least %d", min))
+		}
+
+		return nil
+	}
+}
+
+func validateTimeOfDay(text string) error {
+	if strings.TrimSpace(text) == "" {
+		return nil
+	}
+
+	if _, err := time.Parse("15:04", strings.TrimSpace(text)); err != nil {
+		return errors.New(tr("must be a time like 22:00"))
+	}
+
+	return nil
+}
+
+func validatePatterns(text string) error {
+	for _, pattern := range splitList(text) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return errors.New(tr("invalid pattern %q", pattern))
+		}
+	}
+
+	return nil
+}
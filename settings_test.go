@@ -0,0 +1,80 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestOptionSelectKeepsConfigValues(t *testing.T) {
+	setLocale("de")
+	defer setLocale("en")
+
+	themeSelect, themeValue := newOptionSelect([]string{"", "light", "dark"}, "system", themeLabel)
+
+	if themeSelect.Selected != "System" {
+		t.Errorf("theme system shown as %q", themeSelect.Selected)
+	}
+
+	themeSelect.SetSelected("Dunkel")
+
+	if value := themeValue(); value != "dark" {
+		t.Errorf("Dunkel saved as %q, want dark", value)
+	}
+
+	accentSelect, accentValue := newOptionSelect([]string{"", "blue", "#123456"}, "", accentLabel)
+
+	if accentSelect.Selected != "Standard" || accentValue() != "" {
+		t.Errorf("default accent shown as %q, saved as %q", accentSelect.Selected, accentValue())
+	}
+
+	accentSelect.SetSelected("#123456")
+
+	if value := accentValue(); value != "#123456" {
+		t.Errorf("custom accent saved as %q", value)
+	}
+}
+
+func TestSettingsValidators(t *testing.T) {
+	tests := []struct {
+		name     string
+		validate func(string) error
+		text     string
+		valid    bool
+	}{
+		{"name", validateNotEmpty, "work", true},
+		{"empty name", validateNotEmpty, "  ", false},
+		{"interval", validateMinInt(10), "60", true},
+		{"interval at the minimum", validateMinInt(10), "10", true},
+		{"interval too short", validateMinInt(10), "5", false},
+		{"interval not a number", validateMinInt(10), "1m", false},
+		{"time", validateTimeOfDay, "22:00", true},
+		{"no time", validateTimeOfDay, "", true},
+		{"time out of range", validateTimeOfDay, "25:00", false},
+		{"time not a time", validateTimeOfDay, "late", false},
+		{"patterns", validatePatterns, "octo/*, other/repo", true},
+		{"no patterns", validatePatterns, "", true},
+		{"broken pattern", validatePatterns, "octo/[", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := test.validate(test.text); (err == nil) != test.valid {
+				t.Errorf("%q: error %v, want valid %v", test.text, err, test.valid)
+			}
+		})
+	}
+}
+
+func TestSplitList(t *testing.T) {
+	tests := map[string][]string{
+		"":                       nil,
+		"octo/*":                 {"octo/*"},
+		" octo/* , other/repo, ": {"octo/*", "other/repo"},
+	}
+
+	for text, want := range tests {
+		if got := splitList(text); !reflect.DeepEqual(got, want) {
+			t.Errorf("splitList(%q) = %q, want %q", text, got, want)
+		}
+	}
+}
@@ -0,0 +1,247 @@
+package main
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+
+	"fyne.io/fyne/v2"
+	"github.com/google/go-github/v55/github"
+)
+
+// DEFAULT_KEYMAP maps keyboard actions to keys. Printable keys are written as
+// the character, others by their Fyne key name (Return, Escape, Down...).
+var DEFAULT_KEYMAP = map[string]string{
+	"next":     "j",
+	"previous": "k",
+	"open":     "Return",
+	"read":     "e",
+	"done":     "d",
+	"snooze":   "s",
+	"search":   "/",
+	"refresh":  "r",
+	"undo":     "u",
+}
+
+var keyActions = map[string]func(){
+	"next": func() {
+		moveSelection(1)
+	},
+	"previous": func() {
+		moveSelection(-1)
+	},
+	"open": func() {
+		withSelectedNotification(func(notification *github.Notification) {
+			openURLInBrowser(notificationHTMLURL(notification))
+		})
+	},
+	"read": func() {
+		withSelectedNotification(readNotificationAction)
+	},
+	"done": func() {
+		withSelectedNotification(doneNotificationAction)
+	},
+	"snooze": func() {
+		withSelectedNotification(func(notification *github.Notification) {
+			snoozeNotification(notification, SNOOZE_DURATION)
+		})
+	},
+	"search": func() {
+		openSearch()
+	},
+	"refresh": func() {
+		refreshNotifications()
+	},
+	"undo": func() {
+		if id := currentSnackbarActionID(); id != 0 {
+			undoAction(id)
+		}
+	},
+}
+
+// keymap maps keys to action names. It is replaced on the UI event loop
+// when the config changes and read from Fyne's key callbacks.
+var keymap = struct {
+	sync.Mutex
+	actions map[string]string
+}{}
+
+func addKeyboardShortcuts() {
+	loadKeymap()
+
+	window.Canvas().SetOnTypedRune(func(r rune) {
+		handleKey(string(r))
+	})
+
+	window.Canvas().SetOnTypedKey(func(event *fyne.KeyEvent) {
+		if event.Name == fyne.KeyEscape {
+			closeSearch()
+			return
+		}
+
+		// printable keys also arrive as runes
+		if len(event.Name) > 1 {
+			handleKey(string(event.Name))
+		}
+	})
+}
+
+func loadKeymap() {
+	actionKeys, err := resolveKeymap(currentConfig().Keymap)
+
+	if err != nil {
+		actionKeys = DEFAULT_KEYMAP
+	}
+
+	actions := make(map[string]string, len(actionKeys))
+
+	for action, key := range actionKeys {
+		actions[key] = action
+	}
+
+	keymap.Lock()
+	keymap.actions = actions
+	keymap.Unlock()
+}
+
+func handleKey(key string) {
+	keymap.Lock()
+	action, ok := keymap.actions[key]
+	keymap.Unlock()
+
+	if !ok {
+		return
+	}
+
+	keyActions[action]()
+}
+
+// parseKeymap reads "action=key" pairs separated by commas or new lines,
+// on top of the defaults.
+func parseKeymap(text string) (map[string]string, error) {
+	overrides := make(map[string]string)
+
+	for _, pair := range strings.FieldsFunc(text, func(r rune) bool { return r == ',' || r == '\n' }) {
+		action, key, ok := strings.Cut(strings.TrimSpace(pair), "=")
+
+		if !ok || strings.TrimSpace(key) == "" {
+			return nil, errors.New(tr("invalid key binding %q", pair))
+		}
+
+		overrides[strings.TrimSpace(action)] = strings.TrimSpace(key)
+	}
+
+	return resolveKeymap(overrides)
+}
+
+// resolveKeymap applies overrides to the default keymap and checks that
+// every action exists and every key is bound once.
+func resolveKeymap(overrides map[string]string) (map[string]string, error) {
+	actionKeys := make(map[string]string, len(DEFAULT_KEYMAP))
+
+	for action, key := range DEFAULT_KEYMAP {
+		actionKeys[action] = key
+	}
+
+	for action, key := range overrides {
+		if _, ok := keyActions[action]; !ok {
+			return nil, errors.New(tr("unknown action %q", action))
+		}
+
+		if key == "" {
+			return nil, errors.New(tr("no key for action %q", action))
+		}
+
+		actionKeys[action] = key
+	}
+
+	keys := make(map[string]string, len(actionKeys))
+
+	for action, key := range actionKeys {
+		if other, ok := keys[key]; ok {
+			return nil, errors.New(tr("%q is bound to both %s and %s", key, other, action))
+		}
+
+		keys[key] = action
+	}
+
+	return actionKeys, nil
+}
+
+// keymapOverrides keeps only the bindings that differ from the defaults.
+func keymapOverrides(actionKeys map[string]string) map[string]string {
+	var overrides map[string]string
+
+	for action, key := range actionKeys {
+		if DEFAULT_KEYMAP[action] == key {
+			continue
+		}
+
+		if overrides == nil {
+			overrides = make(map[string]string)
+		}
+
+		overrides[action] = key
+	}
+
+	return overrides
+}
+
+func keymapText() string {
+	actionKeys, err := resolveKeymap(currentConfig().Keymap)
+
+	if err != nil {
+		actionKeys = DEFAULT_KEYMAP
+	}
+
+	actions := make([]string, 0, len(actionKeys))
+
+	for action := range actionKeys {
+		actions = append(actions, action)
+	}
+
+	sort.Strings(actions)
+
+	pairs := make([]string, 0, len(actions))
+
+	for _, action := range actions {
+		pairs = append(pairs, action+"="+actionKeys[action])
+	}
+
+	return strings.Join(pairs, "\n")
+}
+
+func withSelectedNotification(callback func(*github.Notification)) {
+	id, _ := notificationStore.Selected()
+
+	if notification := notificationStore.Find(id); notification != nil {
+		callback(notification)
+	}
+}
+
+func moveSelection(delta int) {
+	length := notificationStore.Len()
+
+	if length == 0 {
+		return
+	}
+
+	_, index := notificationStore.Selected()
+
+	next := index + delta
+
+	if index == -1 {
+		next = 0
+	}
+
+	if next < 0 {
+		next = 0
+	}
+
+	if next >= length {
+		next = length - 1
+	}
+
+	notificationListComponent.Select(next)
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/go-github/v55/github"
+)
+
+func TestParseKeymap(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		// want holds the bindings that differ from the defaults
+		want    map[string]string
+		wantErr bool
+	}{
+		{name: "defaults", text: ""},
+		{name: "one", text: "read=m", want: map[string]string{"read": "m"}},
+		{name: "several", text: "read=m, done = x\nnext=Down", want: map[string]string{"read": "m", "done": "x", "next": "Down"}},
+		{name: "swapped", text: "next=k,previous=j", want: map[string]string{"next": "k", "previous": "j"}},
+		{name: "same as default", text: "read=e"},
+		{name: "no key", text: "read=", wantErr: true},
+		{name: "no equals", text: "read", wantErr: true},
+		{name: "unknown action", text: "launch=l", wantErr: true},
+		{name: "key bound twice", text: "read=d", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actionKeys, err := parseKeymap(test.text)
+
+			if (err != nil) != test.wantErr {
+				t.Fatalf("parseKeymap(%q) error %v, want error %v", test.text, err, test.wantErr)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if got := keymapOverrides(actionKeys); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("parseKeymap(%q) overrides %v, want %v", test.text, got, test.want)
+			}
+
+			if len(actionKeys) != len(DEFAULT_KEYMAP) {
+				t.Errorf("parseKeymap(%q) binds %d actions, want %d", test.text, len(actionKeys), len(DEFAULT_KEYMAP))
+			}
+		})
+	}
+}
+
+func TestMoveSelection(t *testing.T) {
+	useFakeGitHub(t, 0)
+
+	t.Cleanup(func() {
+		runOnUI(func() {
+			notificationListComponent.UnselectAll()
+			hideNotificationDetail()
+		})
+		waitUI(t)
+	})
+
+	runOnUI(func() {
+		addNotifications([]*github.Notification{testGitHub.notification(1), testGitHub.notification(2), testGitHub.notification(3)}, nil)
+		notificationListComponent.UnselectAll()
+	})
+	waitUI(t)
+
+	tests := []struct {
+		delta int
+		want  string
+	}{
+		{1, "1"},
+		{1, "2"},
+		{1, "3"},
+		{1, "3"},
+		{-1, "2"},
+		{-1, "1"},
+		{-1, "1"},
+	}
+
+	for i, test := range tests {
+		runOnUI(func() {
+			moveSelection(test.delta)
+		})
+		waitUI(t)
+
+		if id, _ := notificationStore.Selected(); id != test.want {
+			t.Errorf("step %d: moved %+d to %q, want %q", i, test.delta, id, test.want)
+		}
+	}
+}
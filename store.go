@@ -0,0 +1,339 @@
+package main
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/google/go-github/v55/github"
+)
+
+// NotificationStore holds the fetched notifications and the filtered subset
+// shown in the list, and tells its listeners what changed on every update,
+// so the UI can patch rows in place instead of rebuilding the list. It is
+// safe for concurrent use; listeners are called without the lock held.
+type NotificationStore struct {
+	mutex         sync.RWMutex
+	source        []*github.Notification
+	notifications []*github.Notification
+	filter        func(*github.Notification) bool
+	listeners     []func(StoreChange)
+	selectedID    string
+	selectedIndex int
+}
+
+type StoreChange struct {
+	Inserted []*github.Notification
+	Updated  []*github.Notification
+	Removed  []string
+	Reorder  bool
+}
+
+func newNotificationStore() *NotificationStore {
+	return &NotificationStore{selectedIndex: -1}
+}
+
+func (c *StoreChange) IsEmpty() bool {
+	return len(c.Inserted) == 0 && len(c.Updated) == 0 && len(c.Removed) == 0 && !c.Reorder
+}
+
+// IsStructural reports whether rows were added, removed or moved, in which
+// case row indexes are no longer stable.
+func (c *StoreChange) IsStructural() bool {
+	return len(c.Inserted) != 0 || len(c.Removed) != 0 || c.Reorder
+}
+
+func (s *NotificationStore) Len() int {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return len(s.notifications)
+}
+
+func (s *NotificationStore) At(index int) *github.Notification {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if index < 0 || index >= len(s.notifications) {
+		return nil
+	}
+
+	return s.notifications[index]
+}
+
+func (s *NotificationStore) IndexOf(id string) int {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.indexOf(id)
+}
+
+func (s *NotificationStore) Find(id string) *github.Notification {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	index := s.indexOf(id)
+
+	if index == -1 {
+		return nil
+	}
+
+	return s.notifications[index]
+}
+
+// All returns a copy of the stored notifications.
+func (s *NotificationStore) All() []*github.Notification {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return append([]*github.Notification(nil), s.notifications...)
+}
+
+// Fetched returns a copy of the fetched notifications, regardless of the
+// filter.
+func (s *NotificationStore) Fetched() []*github.Notification {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return append([]*github.Notification(nil), s.source...)
+}
+
+func (s *NotificationStore) Selected() (string, int) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.selectedID, s.selectedIndex
+}
+
+func (s *NotificationStore) SetSelected(id string, index int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.selectedID = id
+	s.selectedIndex = index
+}
+
+func (s *NotificationStore) AddListener(listener func(StoreChange)) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.listeners = append(s.listeners, listener)
+}
+
+// Set replaces the fetched notifications and notifies listeners with the
+// difference to the previously listed ones. It returns the difference to the
+// previously fetched ones, regardless of the filter.
+func (s *NotificationStore) Set(notifications []*github.Notification) StoreChange {
+	s.mutex.Lock()
+	sourceChange := diffNotifications(s.source, notifications)
+	s.source = notifications
+	change, listeners := s.replace(s.filtered())
+	s.mutex.Unlock()
+
+	notifyListeners(listeners, change)
+
+	return sourceChange
+}
+
+// MarkRead flags the notification as read locally, without waiting for the
+// next fetch to confirm it.
+func (s *NotificationStore) MarkRead(id string) {
+	s.mutex.Lock()
+
+	index := s.indexOf(id)
+
+	if index == -1 || !s.notifications[index].GetUnread() {
+		s.mutex.Unlock()
+		return
+	}
+
+	notification := *s.notifications[index]
+	unread := false
+	notification.Unread = &unread
+
+	s.source = replaceNotification(s.source, &notification)
+	change, listeners := s.replace(s.filtered())
+	s.mutex.Unlock()
+
+	notifyListeners(listeners, change)
+}
+
+// Remove drops the notification locally, without waiting for the next fetch.
+func (s *NotificationStore) Remove(id string) {
+	s.mutex.Lock()
+
+	var source []*github.Notification
+
+	for _, notification := range s.source {
+		if notification.GetID() != id {
+			source = append(source, notification)
+		}
+	}
+
+	s.source = source
+	change, listeners := s.replace(s.filtered())
+	s.mutex.Unlock()
+
+	notifyListeners(listeners, change)
+}
+
+// Upsert puts the notification back into the fetched ones, replacing the
+// one with the same ID or inserting it in updated order.
+func (s *NotificationStore) Upsert(notification *github.Notification) {
+	s.mutex.Lock()
+
+	found := false
+
+	for _, n := range s.source {
+		if n.GetID() == notification.GetID() {
+			found = true
+			break
+		}
+	}
+
+	if found {
+		s.source = replaceNotification(s.source, notification)
+	} else {
+		index := sort.Search(len(s.source), func(i int) bool {
+			return s.source[i].GetUpdatedAt().Time.Before(notification.GetUpdatedAt().Time)
+		})
+
+		source := make([]*github.Notification, 0, len(s.source)+1)
+		source = append(source, s.source[:index]...)
+		source = append(source, notification)
+		source = append(source, s.source[index:]...)
+		s.source = source
+	}
+
+	change, listeners := s.replace(s.filtered())
+	s.mutex.Unlock()
+
+	notifyListeners(listeners, change)
+}
+
+// SetFilter changes which of the fetched notifications are listed. A nil
+// filter lists all of them.
+func (s *NotificationStore) SetFilter(filter func(*github.Notification) bool) {
+	s.mutex.Lock()
+	s.filter = filter
+	change, listeners := s.replace(s.filtered())
+	s.mutex.Unlock()
+
+	notifyListeners(listeners, change)
+}
+
+// Refilter applies the current filter again, for filters that depend on
+// time or other outside state.
+func (s *NotificationStore) Refilter() {
+	s.mutex.Lock()
+	change, listeners := s.replace(s.filtered())
+	s.mutex.Unlock()
+
+	notifyListeners(listeners, change)
+}
+
+// filtered must be called with the mutex held.
+func (s *NotificationStore) filtered() []*github.Notification {
+	if s.filter == nil {
+		return s.source
+	}
+
+	var notifications []*github.Notification
+
+	for _, notification := range s.source {
+		if s.filter(notification) {
+			notifications = append(notifications, notification)
+		}
+	}
+
+	return notifications
+}
+
+func replaceNotification(notifications []*github.Notification, notification *github.Notification) []*github.Notification {
+	replaced := make([]*github.Notification, len(notifications))
+
+	for i, n := range notifications {
+		if n.GetID() == notification.GetID() {
+			replaced[i] = notification
+		} else {
+			replaced[i] = n
+		}
+	}
+
+	return replaced
+}
+
+func (s *NotificationStore) Clear() StoreChange {
+	return s.Set(nil)
+}
+
+// replace must be called with the mutex held. It returns the change and a
+// copy of the listeners to notify once the mutex is released.
+func (s *NotificationStore) replace(notifications []*github.Notification) (StoreChange, []func(StoreChange)) {
+	change := diffNotifications(s.notifications, notifications)
+	s.notifications = notifications
+
+	listeners := make([]func(StoreChange), len(s.listeners))
+	copy(listeners, s.listeners)
+
+	return change, listeners
+}
+
+func notifyListeners(listeners []func(StoreChange), change StoreChange) {
+	if change.IsEmpty() {
+		return
+	}
+
+	for _, listener := range listeners {
+		listener(change)
+	}
+}
+
+func (s *NotificationStore) indexOf(id string) int {
+	for i, n := range s.notifications {
+		if n.GetID() == id {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func diffNotifications(previous []*github.Notification, notifications []*github.Notification) StoreChange {
+	var change StoreChange
+
+	indexes := make(map[string]int, len(previous))
+
+	for i, notification := range previous {
+		indexes[notification.GetID()] = i
+	}
+
+	next := make(map[string]bool, len(notifications))
+
+	for i, notification := range notifications {
+		next[notification.GetID()] = true
+
+		index, ok := indexes[notification.GetID()]
+
+		if !ok {
+			change.Inserted = append(change.Inserted, notification)
+			continue
+		}
+
+		if index != i {
+			change.Reorder = true
+		}
+
+		if !previous[index].GetUpdatedAt().Time.Equal(notification.GetUpdatedAt().Time) ||
+			previous[index].GetUnread() != notification.GetUnread() {
+			change.Updated = append(change.Updated, notification)
+		}
+	}
+
+	for _, notification := range previous {
+		if !next[notification.GetID()] {
+			change.Removed = append(change.Removed, notification.GetID())
+		}
+	}
+
+	return change
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v55/github"
+)
+
+var storeTestTime = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func storeNotification(id string, minutes int, unread bool) *github.Notification {
+	return &github.Notification{
+		ID:        github.String(id),
+		Unread:    github.Bool(unread),
+		UpdatedAt: &github.Timestamp{Time: storeTestTime.Add(time.Duration(minutes) * time.Minute)},
+	}
+}
+
+func notificationIDs(notifications []*github.Notification) []string {
+	var ids []string
+
+	for _, notification := range notifications {
+		ids = append(ids, notification.GetID())
+	}
+
+	return ids
+}
+
+func TestDiffNotifications(t *testing.T) {
+	a := storeNotification("a", 3, true)
+	b := storeNotification("b", 2, true)
+	c := storeNotification("c", 1, true)
+
+	tests := []struct {
+		name          string
+		previous      []*github.Notification
+		notifications []*github.Notification
+		inserted      []string
+		updated       []string
+		removed       []string
+		reorder       bool
+	}{
+		{name: "unchanged", previous: []*github.Notification{a, b}, notifications: []*github.Notification{a, b}},
+		{name: "from empty", notifications: []*github.Notification{a, b}, inserted: []string{"a", "b"}},
+		{name: "appended", previous: []*github.Notification{a}, notifications: []*github.Notification{a, b}, inserted: []string{"b"}},
+		{name: "prepended", previous: []*github.Notification{b, c}, notifications: []*github.Notification{a, b, c}, inserted: []string{"a"}, reorder: true},
+		{name: "removed", previous: []*github.Notification{a, b, c}, notifications: []*github.Notification{a, c}, removed: []string{"b"}, reorder: true},
+		{name: "cleared", previous: []*github.Notification{a, b}, removed: []string{"a", "b"}},
+		{name: "moved", previous: []*github.Notification{a, b}, notifications: []*github.Notification{b, a}, reorder: true},
+		{name: "newer", previous: []*github.Notification{a, b}, notifications: []*github.Notification{a, storeNotification("b", 5, true)}, updated: []string{"b"}},
+		{name: "read", previous: []*github.Notification{a, b}, notifications: []*github.Notification{storeNotification("a", 3, false), b}, updated: []string{"a"}},
+		{name: "same values", previous: []*github.Notification{a}, notifications: []*github.Notification{storeNotification("a", 3, true)}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			change := diffNotifications(test.previous, test.notifications)
+
+			if got := notificationIDs(change.Inserted); !reflect.DeepEqual(got, test.inserted) {
+				t.Errorf("inserted %q, want %q", got, test.inserted)
+			}
+
+			if got := notificationIDs(change.Updated); !reflect.DeepEqual(got, test.updated) {
+				t.Errorf("updated %q, want %q", got, test.updated)
+			}
+
+			if !reflect.DeepEqual(change.Removed, test.removed) {
+				t.Errorf("removed %q, want %q", change.Removed, test.removed)
+			}
+
+			if change.Reorder != test.reorder {
+				t.Errorf("reorder %v, want %v", change.Reorder, test.reorder)
+			}
+		})
+	}
+}
+
+func TestNotificationStoreListeners(t *testing.T) {
+	store := newNotificationStore()
+
+	var changes []StoreChange
+
+	store.AddListener(func(change StoreChange) {
+		changes = append(changes, change)
+	})
+
+	a := storeNotification("a", 3, true)
+	b := storeNotification("b", 2, false)
+	c := storeNotification("c", 1, true)
+
+	tests := []struct {
+		name   string
+		update func()
+		listed []string
+		// notified is false when listeners are not called at all
+		notified bool
+		inserted []string
+		updated  []string
+		removed  []string
+	}{
+		{name: "set", update: func() { store.Set([]*github.Notification{a, b}) }, listed: []string{"a", "b"}, notified: true, inserted: []string{"a", "b"}},
+		{name: "set unchanged", update: func() { store.Set([]*github.Notification{a, b}) }, listed: []string{"a", "b"}},
+		{name: "mark read", update: func() { store.MarkRead("a") }, listed: []string{"a", "b"}, notified: true, updated: []string{"a"}},
+		{name: "mark read again", update: func() { store.MarkRead("a") }, listed: []string{"a", "b"}},
+		{name: "upsert new", update: func() { store.Upsert(c) }, listed: []string{"a", "b", "c"}, notified: true, inserted: []string{"c"}},
+		{name: "filter", update: func() { store.SetFilter(func(n *github.Notification) bool { return n.GetUnread() }) }, listed: []string{"c"}, notified: true, removed: []string{"a", "b"}},
+		{name: "upsert filtered out", update: func() { store.Upsert(storeNotification("d", 0, false)) }, listed: []string{"c"}},
+		{name: "remove", update: func() { store.Remove("c") }, listed: nil, notified: true, removed: []string{"c"}},
+		{name: "no filter", update: func() { store.SetFilter(nil) }, listed: []string{"a", "b", "d"}, notified: true, inserted: []string{"a", "b", "d"}},
+		{name: "clear", update: func() { store.Clear() }, listed: nil, notified: true, removed: []string{"a", "b", "d"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			changes = nil
+			test.update()
+
+			if got := notificationIDs(store.All()); !reflect.DeepEqual(got, test.listed) {
+				t.Errorf("listed %q, want %q", got, test.listed)
+			}
+
+			if !test.notified {
+				if len(changes) != 0 {
+					t.Errorf("listeners told of %+v", changes)
+				}
+
+				return
+			}
+
+			if len(changes) != 1 {
+				t.Fatalf("listeners called %d times, want once", len(changes))
+			}
+
+			change := changes[0]
+
+			if got := notificationIDs(change.Inserted); !reflect.DeepEqual(got, test.inserted) {
+				t.Errorf("inserted %q, want %q", got, test.inserted)
+			}
+
+			if got := notificationIDs(change.Updated); !reflect.DeepEqual(got, test.updated) {
+				t.Errorf("updated %q, want %q", got, test.updated)
+			}
+
+			if !reflect.DeepEqual(change.Removed, test.removed) {
+				t.Errorf("removed %q, want %q", change.Removed, test.removed)
+			}
+		})
+	}
+}
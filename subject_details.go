@@ -0,0 +1,420 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/google/go-github/v55/github"
+)
+
+const SUBJECT_DETAILS_WORKERS int = 4
+const COMMENT_EXCERPT_LENGTH int = 80
+
+// SUBJECT_DETAILS_RETRY is how long a failed fetch is remembered before the
+// details are fetched again.
+const SUBJECT_DETAILS_RETRY time.Duration = time.Minute * 5
+
+// SubjectDetails is what we know about the issue, pull request or release
+// behind a notification, beyond what the notifications API returns.
+type SubjectDetails struct {
+	State            string
+	CIStatus         string
+	Labels           []string
+	Assignees        []string
+	Reviewers        []string
+	Author           string
+	AuthorAvatarURL  string
+	Body             string
+	HTMLURL          string
+	CommentAuthor    string
+	CommentAvatarURL string
+	CommentBody      string
+	CommentExcerpt   string
+}
+
+var subjectDetailsCache = struct {
+	sync.Mutex
+	details   map[string]*SubjectDetails
+	pending   map[string]bool
+	failed    map[string]subjectDetailsFailure
+	semaphore chan struct{}
+}{
+	details:   make(map[string]*SubjectDetails),
+	pending:   make(map[string]bool),
+	failed:    make(map[string]subjectDetailsFailure),
+	semaphore: make(chan struct{}, SUBJECT_DETAILS_WORKERS),
+}
+
+type subjectDetailsFailure struct {
+	err  error
+	time time.Time
+}
+
+func subjectDetailsKey(notification *github.Notification) string {
+	return fmt.Sprintf("%s@%d", notification.GetID(), notification.GetUpdatedAt().Unix())
+}
+
+// getSubjectDetails returns the cached details for the notification, or nil
+// while they are fetched in the background or after the fetch failed. onLoad
+// is called once the fetch is over, with nil when it failed. A failed fetch
+// is only tried again after SUBJECT_DETAILS_RETRY.
+func getSubjectDetails(notification *github.Notification, onLoad func(*SubjectDetails)) *SubjectDetails {
+	key := subjectDetailsKey(notification)
+
+	subjectDetailsCache.Lock()
+	defer subjectDetailsCache.Unlock()
+
+	if details, ok := subjectDetailsCache.details[key]; ok {
+		return details
+	}
+
+	if subjectDetailsCache.pending[key] {
+		return nil
+	}
+
+	if failure, ok := subjectDetailsCache.failed[key]; ok && time.Since(failure.time) < SUBJECT_DETAILS_RETRY {
+		return nil
+	}
+
+	subjectDetailsCache.pending[key] = true
+
+	go func() {
+		subjectDetailsCache.semaphore <- struct{}{}
+		details, err := fetchSubjectDetails(globalCtx, notification)
+		<-subjectDetailsCache.semaphore
+
+		subjectDetailsCache.Lock()
+		delete(subjectDetailsCache.pending, key)
+		if err == nil {
+			delete(subjectDetailsCache.failed, key)
+			subjectDetailsCache.details[key] = details
+		} else {
+			subjectDetailsCache.failed[key] = subjectDetailsFailure{err: err, time: time.Now()}
+		}
+		subjectDetailsCache.Unlock()
+
+		if err != nil {
+			slog.Warn("Failed to fetch subject details", "id", notification.GetID(), "err", err)
+			details = nil
+		}
+
+		if onLoad != nil {
+			onLoad(details)
+		}
+	}()
+
+	return nil
+}
+
+// pruneSubjectDetails drops cached details of notifications that are no
+// longer listed.
+func pruneSubjectDetails(notifications []*github.Notification) {
+	keep := make(map[string]bool, len(notifications))
+
+	for _, notification := range notifications {
+		keep[subjectDetailsKey(notification)] = true
+	}
+
+	subjectDetailsCache.Lock()
+	defer subjectDetailsCache.Unlock()
+
+	for key := range subjectDetailsCache.details {
+		if !keep[key] {
+			delete(subjectDetailsCache.details, key)
+		}
+	}
+
+	for key := range subjectDetailsCache.failed {
+		if !keep[key] {
+			delete(subjectDetailsCache.failed, key)
+		}
+	}
+}
+
+// subjectDetailsError is why the details of the notification could not be
+// fetched the last time, or nil.
+func subjectDetailsError(notification *github.Notification) error {
+	subjectDetailsCache.Lock()
+	defer subjectDetailsCache.Unlock()
+
+	if failure, ok := subjectDetailsCache.failed[subjectDetailsKey(notification)]; ok {
+		return failure.err
+	}
+
+	return nil
+}
+
+func fetchSubjectDetails(ctx context.Context, notification *github.Notification) (*SubjectDetails, error) {
+	client := githubClient()
+
+	ctxTimeOut, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	details := &SubjectDetails{}
+	subject := notification.GetSubject()
+
+	var err error
+
+	switch subject.GetType() {
+	case "PullRequest":
+		err = fetchPullRequestDetails(ctxTimeOut, client, notification, details)
+	case "Issue":
+		err = fetchIssueDetails(ctxTimeOut, client, subject.GetURL(), details)
+	case "Release":
+		err = fetchReleaseDetails(ctxTimeOut, client, subject.GetURL(), details)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	commentURL := subject.GetLatestCommentURL()
+
+	if commentURL != "" && commentURL != subject.GetURL() {
+		comment := &github.IssueComment{}
+
+		if err := getGithubURL(ctxTimeOut, client, commentURL, comment); err != nil {
+			slog.Warn("Failed to fetch latest comment", "url", commentURL, "err", err)
+		} else {
+			details.CommentAuthor = comment.GetUser().GetLogin()
+			details.CommentAvatarURL = comment.GetUser().GetAvatarURL()
+			details.CommentBody = comment.GetBody()
+			details.CommentExcerpt = excerpt(comment.GetBody(), COMMENT_EXCERPT_LENGTH)
+		}
+	}
+
+	return details, nil
+}
+
+func fetchPullRequestDetails(ctx context.Context, client *github.Client, notification *github.Notification, details *SubjectDetails) error {
+	pr := &github.PullRequest{}
+
+	if err := getGithubURL(ctx, client, notification.GetSubject().GetURL(), pr); err != nil {
+		return err
+	}
+
+	switch {
+	case pr.GetMerged():
+		details.State = "merged"
+	case pr.GetState() == "closed":
+		details.State = "closed"
+	case pr.GetDraft():
+		details.State = "draft"
+	default:
+		details.State = "open"
+	}
+
+	details.Labels = labelNames(pr.Labels)
+	details.Assignees = userLogins(pr.Assignees)
+	details.Reviewers = userLogins(pr.RequestedReviewers)
+	details.Author = pr.GetUser().GetLogin()
+	details.AuthorAvatarURL = pr.GetUser().GetAvatarURL()
+	details.Body = pr.GetBody()
+	details.HTMLURL = pr.GetHTMLURL()
+
+	if details.State == "open" || details.State == "draft" {
+		owner := notification.GetRepository().GetOwner().GetLogin()
+		repo := notification.GetRepository().GetName()
+
+		details.CIStatus = fetchCIStatus(ctx, client, owner, repo, pr.GetHead().GetSHA())
+	}
+
+	return nil
+}
+
+func fetchIssueDetails(ctx context.Context, client *github.Client, url string, details *SubjectDetails) error {
+	issue := &github.Issue{}
+
+	if err := getGithubURL(ctx, client, url, issue); err != nil {
+		return err
+	}
+
+	details.State = issue.GetState()
+	details.Labels = labelNames(issue.Labels)
+	details.Assignees = userLogins(issue.Assignees)
+	details.Author = issue.GetUser().GetLogin()
+	details.AuthorAvatarURL = issue.GetUser().GetAvatarURL()
+	details.Body = issue.GetBody()
+	details.HTMLURL = issue.GetHTMLURL()
+
+	return nil
+}
+
+func fetchReleaseDetails(ctx context.Context, client *github.Client, url string, details *SubjectDetails) error {
+	release := &github.RepositoryRelease{}
+
+	if err := getGithubURL(ctx, client, url, release); err != nil {
+		return err
+	}
+
+	details.State = "published"
+
+	if release.GetPrerelease() {
+		details.State = "prerelease"
+	}
+
+	details.Author = release.GetAuthor().GetLogin()
+	details.AuthorAvatarURL = release.GetAuthor().GetAvatarURL()
+	details.Body = release.GetBody()
+	details.HTMLURL = release.GetHTMLURL()
+
+	return nil
+}
+
+// fetchCIStatus combines commit statuses and check runs into a single
+// success, failure or pending state. It returns "" when there is no CI.
+func fetchCIStatus(ctx context.Context, client *github.Client, owner string, repo string, sha string) string {
+	if sha == "" {
+		return ""
+	}
+
+	status := ""
+
+	combined, _, err := client.Repositories.GetCombinedStatus(ctx, owner, repo, sha, nil)
+
+	if err == nil && combined.GetTotalCount() > 0 {
+		status = combined.GetState()
+	}
+
+	checkRuns, _, err := client.Checks.ListCheckRunsForRef(ctx, owner, repo, sha, nil)
+
+	if err != nil {
+		return status
+	}
+
+	for _, checkRun := range checkRuns.CheckRuns {
+		switch {
+		case checkRun.GetStatus() != "completed":
+			if status != "failure" {
+				status = "pending"
+			}
+		case checkRun.GetConclusion() == "failure" || checkRun.GetConclusion() == "timed_out" || checkRun.GetConclusion() == "cancelled":
+			status = "failure"
+		case status == "":
+			status = "success"
+		}
+	}
+
+	return status
+}
+
+func getGithubURL(ctx context.Context, client *github.Client, url string, v interface{}) error {
+	req, err := client.NewRequest("GET", url, nil)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = client.Do(ctx, req, v)
+
+	return err
+}
+
+func labelNames(labels []*github.Label) []string {
+	var names []string
+
+	for _, label := range labels {
+		names = append(names, label.GetName())
+	}
+
+	return names
+}
+
+func userLogins(users []*github.User) []string {
+	var logins []string
+
+	for _, user := range users {
+		logins = append(logins, user.GetLogin())
+	}
+
+	return logins
+}
+
+func excerpt(text string, length int) string {
+	text = strings.TrimSpace(text)
+
+	if index := strings.IndexAny(text, "\r\n"); index != -1 {
+		text = text[:index]
+	}
+
+	// cut on graphemes, so a flag or an accented letter is not split
+	if boundaries := graphemeBoundaries(text); len(boundaries) > length {
+		return strings.TrimRightFunc(text[:boundaries[length-1]], unicode.IsSpace) + ELLIPSIS
+	}
+
+	return text
+}
+
+// ActorAvatarURL returns the avatar of whoever triggered the latest activity:
+// the latest commenter, else the subject's author, else fallback.
+func (d *SubjectDetails) ActorAvatarURL(fallback string) string {
+	if d.CommentAvatarURL != "" {
+		return d.CommentAvatarURL
+	}
+
+	if d.AuthorAvatarURL != "" {
+		return d.AuthorAvatarURL
+	}
+
+	return fallback
+}
+
+// Summary is the single line shown under the notification title.
+func (d *SubjectDetails) Summary() string {
+	var parts []string
+
+	if d.CommentAuthor != "" {
+		parts = append(parts, tr("@%s commented: %s", d.CommentAuthor, d.CommentExcerpt))
+	}
+
+	if len(d.Labels) != 0 {
+		parts = append(parts, "["+strings.Join(d.Labels, "] [")+"]")
+	}
+
+	if len(d.Assignees) != 0 {
+		parts = append(parts, "→ @"+strings.Join(d.Assignees, ", @"))
+	}
+
+	return strings.Join(parts, "  ")
+}
+
+// StateText is the translated subject state, with the CI result for open
+// pull requests.
+func (d *SubjectDetails) StateText() string {
+	state := stateName(d.State)
+
+	switch d.CIStatus {
+	case "success":
+		return state + " ✓"
+	case "failure", "error":
+		return state + " ✗"
+	case "pending":
+		return state + " …"
+	}
+
+	return state
+}
+
+func stateName(state string) string {
+	switch state {
+	case "open":
+		return tr("open")
+	case "merged":
+		return tr("merged")
+	case "closed":
+		return tr("closed")
+	case "draft":
+		return tr("draft")
+	case "published":
+		return tr("published")
+	case "prerelease":
+		return tr("prerelease")
+	}
+
+	return state
+}
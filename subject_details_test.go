@@ -0,0 +1,104 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetSubjectDetailsRemembersFailures(t *testing.T) {
+	fake := useFakeGitHub(t, 1)
+	fake.Lock()
+	fake.failDetails = true
+	notification := fake.notification(99)
+	fake.Unlock()
+
+	path := "GET /repos/octo/hello/issues/99"
+	loaded := make(chan *SubjectDetails, 1)
+
+	if details := getSubjectDetails(notification, func(details *SubjectDetails) { loaded <- details }); details != nil {
+		t.Fatal("details returned before they were fetched")
+	}
+
+	select {
+	case details := <-loaded:
+		if details != nil {
+			t.Fatal("failed fetch returned details")
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("onLoad not called after the fetch failed")
+	}
+
+	for i := 0; i < 10; i++ {
+		getSubjectDetails(notification, nil)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+
+	if count := fake.requestCount(path); count != 1 {
+		t.Errorf("failed details fetched %d times", count)
+	}
+
+	if subjectDetailsError(notification) == nil {
+		t.Error("failure not reported")
+	}
+}
+
+func TestStateTextIsTranslated(t *testing.T) {
+	setLocale("de")
+	defer setLocale("en")
+
+	details := &SubjectDetails{State: "merged", CIStatus: "success"}
+
+	if got := details.StateText(); got != "zusammengeführt ✓" {
+		t.Errorf("StateText() = %q", got)
+	}
+
+	if stateColor(details.State) == stateColor("") {
+		t.Error("translated state lost its color")
+	}
+}
+
+func TestExcerpt(t *testing.T) {
+	tests := []struct {
+		name   string
+		text   string
+		length int
+		want   string
+	}{
+		{"fits", "Fix the build", 20, "Fix the build"},
+		{"first line", "Fix the build\r\nand the tests", 20, "Fix the build"},
+		{"cut", "Fix the build on arm64", 7, "Fix the" + ELLIPSIS},
+		{"trailing space", "Fix the build", 4, "Fix" + ELLIPSIS},
+		{"flags", "🇯🇵🇩🇪🇫🇷", 2, "🇯🇵🇩🇪" + ELLIPSIS},
+		{"zwj emoji", "👩‍💻👩‍💻", 1, "👩‍💻" + ELLIPSIS},
+		{"combining marks", "éééé", 2, "éé" + ELLIPSIS},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := excerpt(test.text, test.length); got != test.want {
+				t.Errorf("excerpt(%q, %d) = %q, want %q", test.text, test.length, got, test.want)
+			}
+		})
+	}
+}
+
+func TestActorAvatarURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		details SubjectDetails
+		want    string
+	}{
+		{"commenter", SubjectDetails{CommentAvatarURL: "comment", AuthorAvatarURL: "author"}, "comment"},
+		{"author", SubjectDetails{AuthorAvatarURL: "author"}, "author"},
+		{"fallback", SubjectDetails{}, "owner"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.details.ActorAvatarURL("owner"); got != test.want {
+				t.Errorf("ActorAvatarURL = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,293 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const TASK_RESTART_DELAY time.Duration = time.Second * 5
+const TASK_MAX_RESTART_DELAY time.Duration = time.Minute
+const TASK_MAX_RESTARTS int = 5
+
+// TASK_HEALTHY_TIME is how long a task has to run before a failure no longer
+// counts towards TASK_MAX_RESTARTS.
+const TASK_HEALTHY_TIME time.Duration = time.Minute * 5
+
+type TaskFunc func(ctx context.Context, trigger <-chan struct{}) error
+
+type RestartPolicy int
+
+const (
+	RestartNever RestartPolicy = iota
+	RestartOnFailure
+)
+
+type TaskStatus string
+
+const (
+	TaskRunning    TaskStatus = "running"
+	TaskRestarting TaskStatus = "restarting"
+	TaskStopped    TaskStatus = "stopped"
+	TaskFailed     TaskStatus = "failed"
+)
+
+type TaskInfo struct {
+	Name      string
+	Status    TaskStatus
+	StartedAt time.Time
+	Restarts  int
+	LastError error
+}
+
+type task struct {
+	info    TaskInfo
+	policy  RestartPolicy
+	run     TaskFunc
+	cancel  context.CancelFunc
+	done    chan struct{}
+	trigger chan struct{}
+}
+
+// TaskSupervisor runs named background tasks. Starting a task with a name
+// that is already running stops the old one, and the new one only runs once
+// the old one has exited. Failed or panicking tasks are restarted according
+// to their policy, waiting longer after each failure.
+type TaskSupervisor struct {
+	mutex sync.Mutex
+	ctx   context.Context
+	tasks map[string]*task
+}
+
+var taskSupervisor *TaskSupervisor
+
+func newTaskSupervisor(ctx context.Context) *TaskSupervisor {
+	return &TaskSupervisor{
+		ctx:   ctx,
+		tasks: make(map[string]*task),
+	}
+}
+
+func (s *TaskSupervisor) Start(name string, policy RestartPolicy, run TaskFunc) {
+	ctx, cancel := context.WithCancel(s.ctx)
+
+	t := &task{
+		info:    TaskInfo{Name: name, Status: TaskRunning, StartedAt: time.Now()},
+		policy:  policy,
+		run:     run,
+		cancel:  cancel,
+		done:    make(chan struct{}),
+		trigger: make(chan struct{}, 1),
+	}
+
+	s.mutex.Lock()
+	previous := s.tasks[name]
+	s.tasks[name] = t
+	s.mutex.Unlock()
+
+	if previous != nil {
+		slog.Debug("Replacing task", "task", name)
+		previous.cancel()
+	}
+
+	go s.supervise(ctx, t, previous)
+}
+
+// Stop cancels the named task without waiting for it to exit, so it can be
+// called from the UI.
+func (s *TaskSupervisor) Stop(name string) {
+	s.mutex.Lock()
+	t, ok := s.tasks[name]
+	s.mutex.Unlock()
+
+	if !ok {
+		return
+	}
+
+	slog.Debug("Stopping task", "task", name)
+	t.cancel()
+}
+
+// StopAll cancels every task and waits until they have exited, e.g. before
+// quitting.
+func (s *TaskSupervisor) StopAll() {
+	s.mutex.Lock()
+	tasks := make([]*task, 0, len(s.tasks))
+	for _, t := range s.tasks {
+		tasks = append(tasks, t)
+	}
+	s.mutex.Unlock()
+
+	for _, t := range tasks {
+		t.cancel()
+	}
+
+	for _, t := range tasks {
+		<-t.done
+	}
+}
+
+func (s *TaskSupervisor) Trigger(name string) {
+	s.mutex.Lock()
+	t, ok := s.tasks[name]
+	s.mutex.Unlock()
+
+	if !ok {
+		return
+	}
+
+	select {
+	case t.trigger <- struct{}{}:
+	default:
+	}
+}
+
+func (s *TaskSupervisor) Status() []TaskInfo {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	infos := make([]TaskInfo, 0, len(s.tasks))
+
+	for _, t := range s.tasks {
+		infos = append(infos, t.info)
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Name < infos[j].Name
+	})
+
+	return infos
+}
+
+func (s *TaskSupervisor) setStatus(t *task, status TaskStatus, err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	t.info.Status = status
+
+	if err != nil {
+		t.info.LastError = err
+	}
+}
+
+func (s *TaskSupervisor) supervise(ctx context.Context, t *task, previous *task) {
+	defer close(t.done)
+
+	// tasks of the same name never overlap
+	if previous != nil {
+		<-previous.done
+	}
+
+	for {
+		if ctx.Err() != nil {
+			s.setStatus(t, TaskStopped, nil)
+			return
+		}
+
+		started := time.Now()
+
+		s.mutex.Lock()
+		t.info.Status = TaskRunning
+		t.info.StartedAt = started
+		s.mutex.Unlock()
+
+		err := runTask(ctx, t)
+
+		if ctx.Err() != nil || err == nil {
+			s.setStatus(t, TaskStopped, nil)
+			return
+		}
+
+		slog.Error("Task failed", "task", t.info.Name, "err", err)
+
+		s.mutex.Lock()
+		if time.Since(started) >= TASK_HEALTHY_TIME {
+			t.info.Restarts = 0
+		}
+		canRestart := t.policy == RestartOnFailure && t.info.Restarts < TASK_MAX_RESTARTS
+		if canRestart {
+			t.info.Restarts++
+		}
+		restarts := t.info.Restarts
+		s.mutex.Unlock()
+
+		if !canRestart {
+			s.setStatus(t, TaskFailed, err)
+			return
+		}
+
+		s.setStatus(t, TaskRestarting, err)
+
+		select {
+		case <-ctx.Done():
+			s.setStatus(t, TaskStopped, nil)
+			return
+		case <-time.After(taskRestartDelay(restarts)):
+		}
+	}
+}
+
+// taskRestartDelay doubles TASK_RESTART_DELAY with every restart in a row,
+// up to TASK_MAX_RESTART_DELAY.
+func taskRestartDelay(restarts int) time.Duration {
+	delay := TASK_RESTART_DELAY
+
+	for i := 1; i < restarts && delay < TASK_MAX_RESTART_DELAY; i++ {
+		delay *= 2
+	}
+
+	if delay > TASK_MAX_RESTART_DELAY {
+		return TASK_MAX_RESTART_DELAY
+	}
+
+	return delay
+}
+
+func runTask(ctx context.Context, t *task) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+
+	return t.run(ctx, t.trigger)
+}
+
+func taskStatusText() string {
+	var sb strings.Builder
+
+	for _, info := range taskSupervisor.Status() {
+		sb.WriteString(tr("%s: %s (since %s, restarts: %d)", info.Name, taskStatusName(info.Status), formatTime(info.StartedAt), info.Restarts))
+
+		if info.LastError != nil {
+			sb.WriteString("\n  " + tr("last error: %s", info.LastError))
+		}
+
+		sb.WriteString("\n")
+	}
+
+	if sb.Len() == 0 {
+		return tr("No background tasks")
+	}
+
+	return sb.String()
+}
+
+func taskStatusName(status TaskStatus) string {
+	switch status {
+	case TaskRunning:
+		return tr("running")
+	case TaskRestarting:
+		return tr("restarting")
+	case TaskStopped:
+		return tr("stopped")
+	case TaskFailed:
+		return tr("failed")
+	}
+
+	return string(status)
+}
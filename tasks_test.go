@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTaskSupervisorStartReplacesWithoutOverlap(t *testing.T) {
+	supervisor := newTaskSupervisor(context.Background())
+	defer supervisor.StopAll()
+
+	var running atomic.Int32
+	var overlapped atomic.Bool
+	var runs atomic.Int32
+
+	run := func(ctx context.Context, trigger <-chan struct{}) error {
+		if running.Add(1) > 1 {
+			overlapped.Store(true)
+		}
+		defer running.Add(-1)
+
+		runs.Add(1)
+		<-ctx.Done()
+
+		// slow to exit, like a request in flight
+		time.Sleep(20 * time.Millisecond)
+
+		return nil
+	}
+
+	for i := 0; i < 10; i++ {
+		go supervisor.Start("task", RestartNever, run)
+	}
+
+	eventually(t, 5*time.Second, func() bool {
+		return runs.Load() != 0 && running.Load() == 1
+	})
+
+	supervisor.Start("task", RestartNever, run)
+
+	eventually(t, 5*time.Second, func() bool {
+		infos := supervisor.Status()
+		return len(infos) == 1 && infos[0].Status == TaskRunning && running.Load() == 1
+	})
+
+	if overlapped.Load() {
+		t.Error("two tasks of the same name ran at once")
+	}
+}
+
+func TestTaskSupervisorStopDoesNotWait(t *testing.T) {
+	supervisor := newTaskSupervisor(context.Background())
+
+	supervisor.Start("task", RestartNever, func(ctx context.Context, trigger <-chan struct{}) error {
+		<-ctx.Done()
+		time.Sleep(time.Second)
+		return nil
+	})
+
+	started := time.Now()
+	supervisor.Stop("task")
+
+	if elapsed := time.Since(started); elapsed > 100*time.Millisecond {
+		t.Errorf("Stop waited %s for the task to exit", elapsed)
+	}
+
+	supervisor.StopAll()
+
+	if infos := supervisor.Status(); infos[0].Status != TaskStopped {
+		t.Errorf("status after StopAll = %s", infos[0].Status)
+	}
+}
+
+func TestTaskRestartDelay(t *testing.T) {
+	tests := []struct {
+		restarts int
+		want     time.Duration
+	}{
+		{1, TASK_RESTART_DELAY},
+		{2, 2 * TASK_RESTART_DELAY},
+		{3, 4 * TASK_RESTART_DELAY},
+		{TASK_MAX_RESTARTS, TASK_MAX_RESTART_DELAY},
+	}
+
+	for _, test := range tests {
+		if got := taskRestartDelay(test.restarts); got != test.want {
+			t.Errorf("taskRestartDelay(%d) = %s, want %s", test.restarts, got, test.want)
+		}
+	}
+}
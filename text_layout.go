@@ -0,0 +1,118 @@
+package main
+
+import (
+	"strings"
+	"unicode"
+
+	"fyne.io/fyne/v2"
+	"github.com/rivo/uniseg"
+)
+
+const ELLIPSIS string = "…"
+
+// graphemeBoundaries returns the byte offsets at which text may be cut
+// without splitting a user-perceived character, such as an emoji ZWJ
+// sequence, a flag or a letter with combining marks. The last offset is
+// len(text).
+func graphemeBoundaries(text string) []int {
+	var boundaries []int
+
+	state := -1
+	offset := 0
+
+	for rest := text; len(rest) > 0; {
+		var cluster string
+		cluster, rest, _, state = uniseg.FirstGraphemeClusterInString(rest, state)
+		offset += len(cluster)
+		boundaries = append(boundaries, offset)
+	}
+
+	return boundaries
+}
+
+// lineBreaks returns the byte offsets after which text may wrap by the
+// Unicode line breaking rules: after spaces, between Chinese or Japanese
+// characters, but not before closing punctuation. The last offset is
+// len(text).
+func lineBreaks(text string) []int {
+	var breaks []int
+
+	state := -1
+	offset := 0
+
+	for rest := text; len(rest) > 0; {
+		var segment string
+		segment, rest, _, state = uniseg.FirstLineSegmentInString(rest, state)
+		offset += len(segment)
+		breaks = append(breaks, offset)
+	}
+
+	return breaks
+}
+
+func textWidth(text string, size float32, style fyne.TextStyle) float32 {
+	return fyne.MeasureText(text, size, style).Width
+}
+
+// fittingBoundaries finds, by binary search over the measured widths, how
+// many of the pieces ending at boundaries fit in width after suffix.
+// Trailing spaces are not measured, as they are trimmed.
+func fittingBoundaries(text string, boundaries []int, suffix string, width float32, size float32, style fyne.TextStyle) int {
+	low, high := 0, len(boundaries)
+
+	for low < high {
+		middle := (low + high + 1) / 2
+
+		if textWidth(strings.TrimRightFunc(text[:boundaries[middle-1]], unicode.IsSpace)+suffix, size, style) <= width {
+			low = middle
+		} else {
+			high = middle - 1
+		}
+	}
+
+	return low
+}
+
+// truncateText shortens text to fit width, ending it with an ellipsis.
+func truncateText(text string, width float32, size float32, style fyne.TextStyle) string {
+	if width <= 0 || textWidth(text, size, style) <= width {
+		return text
+	}
+
+	boundaries := graphemeBoundaries(text)
+	count := fittingBoundaries(text, boundaries, ELLIPSIS, width, size, style)
+
+	if count == 0 {
+		return ELLIPSIS
+	}
+
+	return strings.TrimRightFunc(text[:boundaries[count-1]], unicode.IsSpace) + ELLIPSIS
+}
+
+// wrapText breaks text into at most maxLines lines that fit width, at the
+// line breaks of lineBreaks, or between graphemes in a word too long for a
+// line. The last line is truncated if text is left over.
+func wrapText(text string, width float32, size float32, style fyne.TextStyle, maxLines int) []string {
+	if maxLines <= 1 || width <= 0 {
+		return []string{truncateText(text, width, size, style)}
+	}
+
+	var lines []string
+
+	for len(lines) < maxLines-1 && textWidth(text, size, style) > width {
+		breaks := lineBreaks(text)
+		count := fittingBoundaries(text, breaks, "", width, size, style)
+
+		if count == 0 {
+			breaks = graphemeBoundaries(text)
+			count = max(fittingBoundaries(text, breaks, "", width, size, style), 1)
+		}
+
+		end := breaks[count-1]
+
+		lines = append(lines, strings.TrimRightFunc(text[:end], unicode.IsSpace))
+		text = strings.TrimLeftFunc(text[end:], unicode.IsSpace)
+	}
+
+	return append(lines, truncateText(text, width, size, style))
+}
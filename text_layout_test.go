@@ -0,0 +1,104 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"fyne.io/fyne/v2"
+)
+
+func graphemes(text string) []string {
+	var clusters []string
+
+	start := 0
+
+	for _, end := range graphemeBoundaries(text) {
+		clusters = append(clusters, text[start:end])
+		start = end
+	}
+
+	return clusters
+}
+
+func TestGraphemeBoundaries(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{"ascii", "abc", []string{"a", "b", "c"}},
+		{"empty", "", nil},
+		{"cjk", "日本語", []string{"日", "本", "語"}},
+		{"combining marks", "ẹ́a", []string{"ẹ́", "a"}},
+		{"zwj emoji", "👩‍💻x", []string{"👩‍💻", "x"}},
+		{"family", "👨‍👩‍👧‍👦", []string{"👨‍👩‍👧‍👦"}},
+		{"skin tone", "👍🏽👍", []string{"👍🏽", "👍"}},
+		{"flag pairs", "🇯🇵🇩🇪", []string{"🇯🇵", "🇩🇪"}},
+		{"odd regional indicator", "🇯🇵🇩", []string{"🇯🇵", "🇩"}},
+		{"hangul syllable and trailing jamo", "한다", []string{"한", "다"}},
+		{"hangul jamo sequence", "한", []string{"한"}},
+		{"crlf", "a\r\nb", []string{"a", "\r\n", "b"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := graphemes(test.text); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("graphemes(%q) = %q, want %q", test.text, got, test.want)
+			}
+		})
+	}
+}
+
+func TestWrapText(t *testing.T) {
+	const size float32 = 14
+	style := fyne.TextStyle{}
+
+	tests := []struct {
+		name     string
+		text     string
+		fits     string
+		maxLines int
+		want     []string
+	}{
+		{"fits", "Fix the build", "Fix the build", 2, []string{"Fix the build"}},
+		{"words", "Fix the build on arm64", "Fix the build", 2, []string{"Fix the build", "on arm64"}},
+		{"long word", "abcdefgh", "abcd", 2, []string{"abcd", "efgh"}},
+		{"cjk", "日本語日本語", "日本語", 2, []string{"日本語", "日本語"}},
+		{"cjk closing punctuation", "日本語。日本", "日本語", 3, []string{"日本", "語。日", "本"}},
+		{"zwj emoji", "👩‍💻👩‍💻", "👩‍💻", 2, []string{"👩‍💻", "👩‍💻"}},
+		{"flags", "🇯🇵🇩🇪", "🇯🇵", 2, []string{"🇯🇵", "🇩🇪"}},
+		{"combining marks", "éééé", "éé", 2, []string{"éé", "éé"}},
+		{"hangul trailing jamo", "한한", "한", 2, []string{"한", "한"}},
+		{"one line", "Fix the build on arm64", "Fix the build", 1, []string{truncateText("Fix the build on arm64", textWidth("Fix the build", size, style), size, style)}},
+		{"truncated last line", "one two three", "one", 2, []string{"one", truncateText("two three", textWidth("one", size, style), size, style)}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			width := textWidth(test.fits, size, style)
+			got := wrapText(test.text, width, size, style, test.maxLines)
+
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("wrapText(%q) = %q, want %q", test.text, got, test.want)
+			}
+
+			if len(got) > test.maxLines {
+				t.Errorf("%d lines, want at most %d", len(got), test.maxLines)
+			}
+		})
+	}
+}
+
+func TestTruncateTextKeepsGraphemes(t *testing.T) {
+	const size float32 = 14
+	style := fyne.TextStyle{}
+
+	text := "🇯🇵🇩🇪🇫🇷🇪🇸"
+	width := textWidth("🇯🇵🇩🇪"+ELLIPSIS, size, style)
+	got := truncateText(text, width, size, style)
+
+	if !strings.HasSuffix(got, ELLIPSIS) || len(strings.TrimSuffix(got, ELLIPSIS))%8 != 0 {
+		t.Errorf("truncateText(%q) = %q splits a flag", text, got)
+	}
+}
@@ -1,27 +1,135 @@
 package main
 
 import (
+	"errors"
+	"fmt"
 	"image/color"
+	"sort"
+	"strings"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/theme"
 )
 
-type myTheme struct{}
+// ACCENT_COLORS are the accents offered in the settings window. The config
+// file also takes any "#rrggbb" color.
+var ACCENT_COLORS = map[string]color.Color{
+	"blue":   color.RGBA{30, 144, 255, 255},
+	"green":  color.RGBA{46, 160, 67, 255},
+	"orange": color.RGBA{219, 109, 40, 255},
+	"pink":   color.RGBA{219, 97, 162, 255},
+	"purple": color.RGBA{137, 87, 229, 255},
+	"red":    color.RGBA{218, 54, 51, 255},
+}
+
+// customColors holds the app's own colors for the light and dark variants.
+var customColors = map[fyne.ThemeColorName][2]color.Color{
+	"StatusUnread":    {color.RGBA{30, 144, 255, 255}, color.RGBA{88, 166, 255, 255}}, // DodgerBlue
+	"StatusRead":      {color.RGBA{167, 167, 168, 255}, color.RGBA{110, 118, 129, 255}},
+	"Time":            {color.RGBA{120, 120, 119, 255}, color.RGBA{139, 148, 158, 255}},
+	"NType":           {color.RGBA{120, 120, 119, 255}, color.RGBA{139, 148, 158, 255}},
+	"StateOpen":       {color.RGBA{26, 127, 55, 255}, color.RGBA{63, 185, 80, 255}}, // Green
+	"StatePublished":  {color.RGBA{26, 127, 55, 255}, color.RGBA{63, 185, 80, 255}},
+	"StateMerged":     {color.RGBA{130, 80, 223, 255}, color.RGBA{163, 113, 247, 255}}, // Purple
+	"StatePrerelease": {color.RGBA{130, 80, 223, 255}, color.RGBA{163, 113, 247, 255}},
+	"StateClosed":     {color.RGBA{207, 34, 46, 255}, color.RGBA{248, 81, 73, 255}}, // Red
+	"StateDraft":      {color.RGBA{120, 120, 119, 255}, color.RGBA{139, 148, 158, 255}},
+}
+
+// highContrastColors replace both the custom and some of Fyne's colors when
+// high contrast is on.
+var highContrastColors = map[fyne.ThemeColorName][2]color.Color{
+	"StatusUnread":             {color.RGBA{0, 70, 200, 255}, color.RGBA{121, 192, 255, 255}},
+	"StatusRead":               {color.RGBA{80, 80, 80, 255}, color.RGBA{200, 200, 200, 255}},
+	"Time":                     {color.Black, color.White},
+	"NType":                    {color.Black, color.White},
+	"StateOpen":                {color.RGBA{0, 100, 0, 255}, color.RGBA{86, 211, 100, 255}},
+	"StatePublished":           {color.RGBA{0, 100, 0, 255}, color.RGBA{86, 211, 100, 255}},
+	"StateMerged":              {color.RGBA{90, 30, 170, 255}, color.RGBA{210, 168, 255, 255}},
+	"StatePrerelease":          {color.RGBA{90, 30, 170, 255}, color.RGBA{210, 168, 255, 255}},
+	"StateClosed":              {color.RGBA{160, 0, 0, 255}, color.RGBA{255, 123, 114, 255}},
+	"StateDraft":               {color.Black, color.White},
+	theme.ColorNameForeground:  {color.Black, color.White},
+	theme.ColorNameBackground:  {color.White, color.Black},
+	theme.ColorNamePlaceHolder: {color.RGBA{60, 60, 60, 255}, color.RGBA{200, 200, 200, 255}},
+	theme.ColorNameDisabled:    {color.RGBA{90, 90, 90, 255}, color.RGBA{170, 170, 170, 255}},
+	theme.ColorNameSeparator:   {color.Black, color.White},
+	theme.ColorNameInputBorder: {color.Black, color.White},
+}
+
+type myTheme struct {
+	// variant is used instead of the system one when forceVariant is set.
+	variant      fyne.ThemeVariant
+	forceVariant bool
+	accent       color.Color
+	highContrast bool
+	// textScale multiplies text and inline icon sizes on top of Fyne's own
+	// scale setting.
+	textScale float32
+}
 
 var _ fyne.Theme = (*myTheme)(nil)
 
+// applyAppearance switches the app to the configured theme. Fyne redraws
+// every window, so this takes effect without a restart.
+func applyAppearance(appearance AppearanceConfig) {
+	t := &myTheme{highContrast: appearance.HighContrast, textScale: 1}
+
+	if appearance.TextScale > 0 {
+		t.textScale = float32(appearance.TextScale) / 100
+	}
+
+	switch appearance.Theme {
+	case "light":
+		t.variant, t.forceVariant = theme.VariantLight, true
+	case "dark":
+		t.variant, t.forceVariant = theme.VariantDark, true
+	}
+
+	if appearance.Accent != "" {
+		t.accent, _ = parseAccent(appearance.Accent)
+	}
+
+	notifierApp.Settings().SetTheme(t)
+}
+
+func (t *myTheme) currentVariant() fyne.ThemeVariant {
+	if t.forceVariant {
+		return t.variant
+	}
+
+	return fyne.CurrentApp().Settings().ThemeVariant()
+}
+
 func (t *myTheme) Color(name fyne.ThemeColorName, variant fyne.ThemeVariant) color.Color {
-	if name == "StatusUnread" {
-		return color.RGBA{30, 144, 255, 255} // DodgerBlue
+	if t.forceVariant {
+		variant = t.variant
 	}
 
-	if name == "StatusRead" {
-		return color.RGBA{167, 167, 168, 255} // Gray-White
+	index := 0
+
+	if variant == theme.VariantDark {
+		index = 1
 	}
 
-	if name == "Time" || name == "NType" {
-		return color.RGBA{120, 120, 119, 255}
+	if t.accent != nil {
+		switch name {
+		case theme.ColorNamePrimary, theme.ColorNameHyperlink, "StatusUnread":
+			return t.accent
+		case theme.ColorNameFocus, theme.ColorNameSelection:
+			r, g, b, _ := t.accent.RGBA()
+			return color.NRGBA{uint8(r >> 8), uint8(g >> 8), uint8(b >> 8), 0x40}
+		}
+	}
+
+	if t.highContrast {
+		if colors, ok := highContrastColors[name]; ok {
+			return colors[index]
+		}
+	}
+
+	if colors, ok := customColors[name]; ok {
+		return colors[index]
 	}
 
 	return theme.DefaultTheme().Color(name, variant)
@@ -32,7 +140,7 @@ func (t *myTheme) Font(style fyne.TextStyle) fyne.Resource {
 }
 
 func (t *myTheme) Icon(name fyne.ThemeIconName) fyne.Resource {
-	currentThemeVariant := fyne.CurrentApp().Settings().ThemeVariant()
+	currentThemeVariant := t.currentVariant()
 
 	if name == "Settings" {
 		if currentThemeVariant == theme.VariantDark {
@@ -50,9 +158,79 @@ func (t *myTheme) Icon(name fyne.ThemeIconName) fyne.Resource {
 		return resourceGithubPng
 	}
 
+	if name == "PullRequest" {
+		return theme.NewThemedResource(resourceTypePullRequestSvg)
+	}
+
+	if name == "Issue" {
+		return theme.NewThemedResource(resourceTypeIssueSvg)
+	}
+
+	if name == "Release" {
+		return theme.NewThemedResource(resourceTypeReleaseSvg)
+	}
+
+	if name == "Discussion" {
+		return theme.NewThemedResource(resourceTypeDiscussionSvg)
+	}
+
+	if name == "Commit" {
+		return theme.NewThemedResource(resourceTypeCommitSvg)
+	}
+
 	return theme.DefaultTheme().Icon(name)
 }
 
 func (t *myTheme) Size(name fyne.ThemeSizeName) float32 {
-	return theme.DefaultTheme().Size(name)
+	size := theme.DefaultTheme().Size(name)
+
+	switch name {
+	case theme.SizeNameText, theme.SizeNameCaptionText, theme.SizeNameHeadingText,
+		theme.SizeNameSubHeadingText, theme.SizeNameInlineIcon:
+		if t.textScale > 0 {
+			return size * t.textScale
+		}
+	}
+
+	return size
+}
+
+// themeColor looks up a color for the variant currently shown.
+func themeColor(name fyne.ThemeColorName) color.Color {
+	settings := fyne.CurrentApp().Settings()
+
+	return settings.Theme().Color(name, settings.ThemeVariant())
+}
+
+// parseAccent reads an accent name from ACCENT_COLORS or a "#rrggbb" color.
+func parseAccent(text string) (color.Color, error) {
+	text = strings.ToLower(strings.TrimSpace(text))
+
+	if accent, ok := ACCENT_COLORS[text]; ok {
+		return accent, nil
+	}
+
+	var r, g, b uint8
+
+	if len(text) != 7 || text[0] != '#' {
+		return nil, errors.New(tr("%q is not one of %s or a color like #1e90ff", text, strings.Join(accentNames(), ", ")))
+	}
+
+	if _, err := fmt.Sscanf(text, "#%02x%02x%02x", &r, &g, &b); err != nil {
+		return nil, errors.New(tr("%q is not a color like #1e90ff", text))
+	}
+
+	return color.RGBA{r, g, b, 255}, nil
+}
+
+func accentNames() []string {
+	names := make([]string, 0, len(ACCENT_COLORS))
+
+	for name := range ACCENT_COLORS {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
 }
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"image/color"
+	"strings"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/theme"
+)
+
+func TestSubjectTypeIcon(t *testing.T) {
+	// applied before returning, so no later test races with the theme change
+	previous := notifierApp.Settings().Theme()
+	test.ApplyTheme(t, &myTheme{})
+	defer test.ApplyTheme(t, previous)
+
+	tests := map[string]string{
+		"PullRequest":             "type-pull-request.svg",
+		"Issue":                   "type-issue.svg",
+		"Release":                 "type-release.svg",
+		"Discussion":              "type-discussion.svg",
+		"Commit":                  "type-commit.svg",
+		"CheckSuite":              "",
+		"RepositoryVulnerability": "",
+	}
+
+	for subjectType, want := range tests {
+		icon := subjectTypeIcon(subjectType)
+
+		if want == "" {
+			if icon != nil {
+				t.Errorf("subjectTypeIcon(%q) = %s, want no badge", subjectType, icon.Name())
+			}
+
+			continue
+		}
+
+		if icon == nil || !strings.HasSuffix(icon.Name(), want) {
+			t.Errorf("subjectTypeIcon(%q) = %v, want %s", subjectType, icon, want)
+		}
+	}
+}
+
+func sameColor(a, b color.Color) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	ar, ag, ab, aa := a.RGBA()
+	br, bg, bb, ba := b.RGBA()
+
+	return ar == br && ag == bg && ab == bb && aa == ba
+}
+
+func TestThemeColor(t *testing.T) {
+	blue := ACCENT_COLORS["blue"]
+
+	tests := []struct {
+		name    string
+		theme   *myTheme
+		color   fyne.ThemeColorName
+		variant fyne.ThemeVariant
+		want    color.Color
+	}{
+		{"custom light", &myTheme{}, "StatusUnread", theme.VariantLight, customColors["StatusUnread"][0]},
+		{"custom dark", &myTheme{}, "StatusUnread", theme.VariantDark, customColors["StatusUnread"][1]},
+		{"forced variant", &myTheme{variant: theme.VariantDark, forceVariant: true}, "Time", theme.VariantLight, customColors["Time"][1]},
+		{"fyne color", &myTheme{}, theme.ColorNameForeground, theme.VariantLight, theme.DefaultTheme().Color(theme.ColorNameForeground, theme.VariantLight)},
+		{"accent primary", &myTheme{accent: blue}, theme.ColorNamePrimary, theme.VariantDark, blue},
+		{"accent unread", &myTheme{accent: blue}, "StatusUnread", theme.VariantLight, blue},
+		{"accent selection", &myTheme{accent: blue}, theme.ColorNameSelection, theme.VariantLight, color.NRGBA{30, 144, 255, 0x40}},
+		{"accent leaves others", &myTheme{accent: blue}, "StatusRead", theme.VariantLight, customColors["StatusRead"][0]},
+		{"high contrast light", &myTheme{highContrast: true}, theme.ColorNameForeground, theme.VariantLight, color.Black},
+		{"high contrast dark", &myTheme{highContrast: true}, theme.ColorNameBackground, theme.VariantDark, color.Black},
+		{"high contrast custom", &myTheme{highContrast: true}, "NType", theme.VariantDark, color.White},
+		{"high contrast keeps the accent", &myTheme{highContrast: true, accent: blue}, "StatusUnread", theme.VariantLight, blue},
+		{"high contrast without accent", &myTheme{highContrast: true}, theme.ColorNamePrimary, theme.VariantLight, theme.DefaultTheme().Color(theme.ColorNamePrimary, theme.VariantLight)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.theme.Color(test.color, test.variant); !sameColor(got, test.want) {
+				t.Errorf("Color(%s) = %v, want %v", test.color, got, test.want)
+			}
+		})
+	}
+}
+
+func TestParseAccent(t *testing.T) {
+	tests := []struct {
+		text string
+		want color.Color
+	}{
+		{"blue", ACCENT_COLORS["blue"]},
+		{" Green ", ACCENT_COLORS["green"]},
+		{"#1e90ff", color.RGBA{30, 144, 255, 255}},
+		{"#1E90FF", color.RGBA{30, 144, 255, 255}},
+		{"navy", nil},
+		{"#1e90f", nil},
+		{"#zzzzzz", nil},
+	}
+
+	for _, test := range tests {
+		got, err := parseAccent(test.text)
+
+		if (err == nil) != (test.want != nil) {
+			t.Errorf("parseAccent(%q) error %v", test.text, err)
+			continue
+		}
+
+		if !sameColor(got, test.want) {
+			t.Errorf("parseAccent(%q) = %v, want %v", test.text, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/electricbubble/go-toast"
+	"github.com/google/go-github/v55/github"
+)
+
+const TOKEN_EXPIRY_WARNING time.Duration = time.Hour * 24 * 7
+const TOKEN_SETTINGS_URL string = "https://github.com/settings/tokens"
+
+// TokenInfo is what GitHub says about a token.
+type TokenInfo struct {
+	Login string
+	// Scopes is nil for fine-grained tokens, which have permissions instead.
+	Scopes     []string
+	Expiration time.Time
+	Warnings   []string
+}
+
+// TokenError is a problem with the token itself, as opposed to not being
+// able to reach GitHub. Saving such a token is refused.
+type TokenError struct {
+	Message string
+}
+
+func (e *TokenError) Error() string {
+	return e.Message
+}
+
+// checkToken asks GitHub who the token belongs to and makes sure it can
+// read notifications.
+func checkToken(ctx context.Context, token string) (*TokenInfo, error) {
+	token = strings.TrimSpace(token)
+
+	if token == "" {
+		return nil, &TokenError{tr("No token entered.")}
+	}
+
+	ctxTimeOut, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	client := newGitHubClient(token)
+
+	user, resp, err := client.Users.Get(ctxTimeOut, "")
+
+	if err != nil {
+		return nil, tokenError(err)
+	}
+
+	info := &TokenInfo{
+		Login:      user.GetLogin(),
+		Expiration: tokenExpiration(resp.Header),
+	}
+
+	if _, ok := resp.Header["X-Oauth-Scopes"]; ok {
+		info.Scopes = splitList(resp.Header.Get("X-OAuth-Scopes"))
+
+		if !hasScope(info.Scopes, "notifications") && !hasScope(info.Scopes, "repo") {
+			return info, &TokenError{tr(
+				"The token for %s has the scopes [%s] but needs notifications (or repo) to read notifications. Add it at %s.",
+				info.Login, strings.Join(info.Scopes, ", "), TOKEN_SETTINGS_URL)}
+		}
+
+		if !hasScope(info.Scopes, "repo") {
+			info.Warnings = append(info.Warnings, tr("Without the repo scope, details from private repositories will not load."))
+		}
+	}
+
+	// fine-grained tokens list no scopes, so try the endpoint itself
+	_, _, err = client.Activity.ListNotifications(ctxTimeOut, &github.NotificationListOptions{
+		ListOptions: github.ListOptions{PerPage: 1},
+	})
+
+	if err != nil {
+		var errorResponse *github.ErrorResponse
+
+		// only a refusal means the token type is the problem
+		if errors.As(err, &errorResponse) && info.Scopes == nil && errorResponse.Response != nil &&
+			(errorResponse.Response.StatusCode == http.StatusForbidden || errorResponse.Response.StatusCode == http.StatusNotFound) {
+			return info, &TokenError{tr(
+				"The token for %s cannot read notifications. Fine-grained tokens do not support the notifications API; use a classic token with the notifications scope from %s.",
+				info.Login, TOKEN_SETTINGS_URL)}
+		}
+
+		return info, tokenError(err)
+	}
+
+	if !info.Expiration.IsZero() && time.Until(info.Expiration) < TOKEN_EXPIRY_WARNING {
+		info.Warnings = append(info.Warnings, expiryText(info.Expiration))
+	}
+
+	return info, nil
+}
+
+// tokenError explains failures caused by the token, and passes anything
+// else through.
+func tokenError(err error) error {
+	var errorResponse *github.ErrorResponse
+
+	if !errors.As(err, &errorResponse) || errorResponse.Response == nil {
+		return err
+	}
+
+	switch errorResponse.Response.StatusCode {
+	case http.StatusUnauthorized:
+		return &TokenError{tr("GitHub rejected the token. It is invalid, revoked or expired.")}
+	case http.StatusForbidden:
+		if errorResponse.Response.Header.Get("X-RateLimit-Remaining") == "0" {
+			return err
+		}
+
+		return &TokenError{tr("The token is not allowed to do this: %s", errorResponse.Message)}
+	}
+
+	return err
+}
+
+func hasScope(scopes []string, scope string) bool {
+	for _, s := range scopes {
+		if s == scope {
+			return true
+		}
+	}
+
+	return false
+}
+
+func tokenExpiration(header http.Header) time.Time {
+	value := header.Get("GitHub-Authentication-Token-Expiration")
+
+	if value == "" {
+		return time.Time{}
+	}
+
+	for _, layout := range []string{"2006-01-02 15:04:05 MST", "2006-01-02 15:04:05 -0700"} {
+		if expiration, err := time.Parse(layout, value); err == nil {
+			return expiration
+		}
+	}
+
+	slog.Warn("Unknown token expiration", "value", value)
+
+	return time.Time{}
+}
+
+func expiryText(expiration time.Time) string {
+	days := int(time.Until(expiration).Hours() / 24)
+
+	if days < 1 {
+		return tr("The token expires today at %s.", formatTime(expiration))
+	}
+
+	return trn("The token expires in %d day, on %s.", "The token expires in %d days, on %s.", days, formatDate(expiration))
+}
+
+func tokenInfoText(info *TokenInfo) string {
+	scopes := tr("none listed (fine-grained token)")
+
+	if info.Scopes != nil {
+		scopes = strings.Join(info.Scopes, ", ")
+	}
+
+	text := tr("Connected as %s", info.Login) + "\n" + tr("Scopes: %s", scopes)
+
+	if !info.Expiration.IsZero() {
+		text += "\n" + tr("Expires: %s", formatDateTime(info.Expiration))
+	}
+
+	for _, warning := range info.Warnings {
+		text += "\n" + warning
+	}
+
+	return text
+}
+
+// recordTokenExpiration warns once a day when the token in use is about
+// to expire.
+func recordTokenExpiration(header http.Header) {
+	expiration := tokenExpiration(header)
+
+	if expiration.IsZero() || time.Until(expiration) > TOKEN_EXPIRY_WARNING {
+		return
+	}
+
+	today := time.Now().Format(time.DateOnly)
+
+	if notifierApp.Preferences().String("token_expiry_warned") == today {
+		return
+	}
+
+	notifierApp.Preferences().SetString("token_expiry_warned", today)
+
+	_ = toast.Push(expiryText(expiration)+" "+tr("Create a new one and update it in Settings."),
+		toast.WithTitle(tr("GitHub token expiring")),
+		toast.WithObjectiveC(true),
+	)
+}
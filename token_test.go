@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestCheckTokenErrorsAreTranslated(t *testing.T) {
+	setLocale("de")
+	defer setLocale("en")
+
+	_, err := checkToken(context.Background(), " ")
+
+	var tokenErr *TokenError
+
+	if !errors.As(err, &tokenErr) || tokenErr.Message != "Kein Token eingegeben." {
+		t.Errorf("checkToken without a token = %v", err)
+	}
+}
+
+func TestCheckToken(t *testing.T) {
+	useFakeGitHub(t, 1)
+
+	info, err := checkToken(context.Background(), "test-token")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if info.Login != "octo" || len(info.Warnings) != 0 {
+		t.Errorf("checkToken = %+v", info)
+	}
+}
+
+func TestCheckTokenFineGrained(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		fineGrained bool
+	}{
+		{"forbidden", http.StatusForbidden, true},
+		{"not found", http.StatusNotFound, true},
+		{"server error", http.StatusInternalServerError, false},
+		{"unauthorized", http.StatusUnauthorized, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fake := useFakeGitHub(t, 0)
+			fake.Lock()
+			fake.fineGrained = true
+			fake.notificationsStatus = test.status
+			fake.Unlock()
+
+			_, err := checkToken(context.Background(), "test-token")
+
+			if err == nil {
+				t.Fatal("checkToken succeeded")
+			}
+
+			if got := strings.Contains(err.Error(), "Fine-grained tokens"); got != test.fineGrained {
+				t.Errorf("checkToken = %q, fine-grained message %v, want %v", err, got, test.fineGrained)
+			}
+		})
+	}
+}
@@ -0,0 +1,842 @@
+package main
+
+// LANGUAGES are the bundled translations. English needs no messages, as
+// they are keyed by the English text.
+var LANGUAGES = map[string]*Language{
+	"en": {
+		Name:   "English",
+		Plural: pluralOneOther,
+		DateLayout: map[string]string{
+			"":   "02 Jan 2006",
+			"US": "Jan 2, 2006",
+		},
+		TimeLayout: map[string]string{
+			"":   "15:04",
+			"US": "3:04 PM",
+		},
+	},
+	"de": {
+		Name:       "Deutsch",
+		Plural:     pluralOneOther,
+		DateLayout: map[string]string{"": "02.01.2006"},
+		TimeLayout: map[string]string{"": "15:04"},
+		Messages: map[string][]string{
+			"Loading...":                     {"Wird geladen …"},
+			"Add a GitHub token in Settings": {"Trage in den Einstellungen ein GitHub-Token ein"},
+			"Update it in Settings.":         {"Aktualisiere es in den Einstellungen."},
+			"Failed to fetch notifications":  {"Benachrichtigungen konnten nicht geladen werden"},
+			"No Notifications":               {"Keine Benachrichtigungen"},
+			"No New Notifications":           {"Keine neuen Benachrichtigungen"},
+			"You have %d new notification":   {"Du hast %d neue Benachrichtigung", "Du hast %d neue Benachrichtigungen"},
+			"Search notifications":           {"Benachrichtigungen durchsuchen"},
+			"Show":                           {"Anzeigen"},
+			"Hook Log":                       {"Hook-Protokoll"},
+			"Background Tasks":               {"Hintergrundaufgaben"},
+			"Outbox":                         {"Ausgang"},
+			"Quit":                           {"Beenden"},
+			"Close":                          {"Schließen"},
+			"Read":                           {"Gelesen"},
+			"Open":                           {"Öffnen"},
+			"Done":                           {"Erledigt"},
+			"Unsubscribe":                    {"Abbestellen"},
+			"Snooze":                         {"Zurückstellen"},
+			"Clear":                          {"Aufheben"},
+			"%d selected":                    {"%d ausgewählt"},
+			"Undo":                           {"Rückgängig"},
+			"Marked as read":                 {"Als gelesen markiert"},
+			"Marked as done":                 {"Als erledigt markiert"},
+			"Unsubscribed":                   {"Abbestellt"},
+			"Just now":                       {"Gerade eben"},
+			"%d minute ago":                  {"vor %d Minute", "vor %d Minuten"},
+			"%d hour ago":                    {"vor %d Stunde", "vor %d Stunden"},
+			"%d day ago":                     {"vor %d Tag", "vor %d Tagen"},
+			"State: %s":                      {"Status: %s"},
+			"Author: %s":                     {"Autor: %s"},
+			"Labels: %s":                     {"Labels: %s"},
+			"Assignees: %s":                  {"Zugewiesen: %s"},
+			"Reviewers: %s":                  {"Reviewer: %s"},
+			"Recent comments":                {"Neueste Kommentare"},
+			"Failed to load comments":        {"Kommentare konnten nicht geladen werden"},
+			"No comments":                    {"Keine Kommentare"},
+			"Leave a comment":                {"Kommentar schreiben"},
+			"Comment":                        {"Kommentieren"},
+			"Approve":                        {"Genehmigen"},
+			"Request changes":                {"Änderungen anfordern"},
+			"Post comment?":                  {"Kommentar veröffentlichen?"},
+			"Add reaction?":                  {"Reaktion hinzufügen?"},
+			"Approve pull request?":          {"Pull Request genehmigen?"},
+			"Request changes?":               {"Änderungen anfordern?"},
+			"Settings":                       {"Einstellungen"},
+			"Accounts":                       {"Konten"},
+			"Polling":                        {"Abfrage"},
+			"Notifications":                  {"Benachrichtigungen"},
+			"Filters":                        {"Filter"},
+			"Appearance":                     {"Darstellung"},
+			"Advanced":                       {"Erweitert"},
+			"Name":                           {"Name"},
+			"Token":                          {"Token"},
+			"Enter GitHub token":             {"GitHub-Token eingeben"},
+			"Test connection":                {"Verbindung testen"},
+			"Connecting...":                  {"Verbinde …"},
+			"Checking token...":              {"Token wird geprüft …"},
+			"Interval (s)":                   {"Intervall (s)"},
+			"Lookback (days)":                {"Zeitraum (Tage)"},
+			"Adaptive":                       {"Adaptiv"},
+			"Poll faster when active, slower when idle": {"Bei Aktivität häufiger, im Leerlauf seltener abfragen"},
+			"Quiet hours":               {"Ruhezeit"},
+			"Do not disturb":            {"Nicht stören"},
+			"From":                      {"Von"},
+			"Until":                     {"Bis"},
+			"Hooks":                     {"Hooks"},
+			"Reasons":                   {"Gründe"},
+			"Repositories":              {"Repositorys"},
+			"Exclude":                   {"Ausschließen"},
+			"Theme":                     {"Design"},
+			"Accent":                    {"Akzent"},
+			"Text size":                 {"Textgröße"},
+			"Title lines":               {"Titelzeilen"},
+			"Contrast":                  {"Kontrast"},
+			"High contrast":             {"Hoher Kontrast"},
+			"List":                      {"Liste"},
+			"Show read notifications":   {"Gelesene Benachrichtigungen anzeigen"},
+			"Language":                  {"Sprache"},
+			"System":                    {"System"},
+			"Keymap":                    {"Tastenbelegung"},
+			"Config file":               {"Konfigurationsdatei"},
+			"Save":                      {"Speichern"},
+			"Cancel":                    {"Abbrechen"},
+			"Could not check the token": {"Das Token konnte nicht geprüft werden"},
+			"%s\n\nSave it anyway?":     {"%s\n\nTrotzdem speichern?"},
+			"GitHub token":              {"GitHub-Token"},
+			"GitHub token expiring":     {"GitHub-Token läuft ab"},
+			"Create a new one and update it in Settings.":    {"Erstelle ein neues und trage es in den Einstellungen ein."},
+			"The token expires today at %s.":                 {"Das Token läuft heute um %s ab."},
+			"The token expires in %d day, on %s.":            {"Das Token läuft in %d Tag ab, am %s.", "Das Token läuft in %d Tagen ab, am %s."},
+			"Connected as %s":                                {"Verbunden als %s"},
+			"Scopes: %s":                                     {"Berechtigungen: %s"},
+			"none listed (fine-grained token)":               {"keine angegeben (Fine-grained-Token)"},
+			"Expires: %s":                                    {"Läuft ab: %s"},
+			"Changing the language takes effect on restart.": {"Die Sprache wird nach einem Neustart übernommen."},
+			"Startup":                       {"Start"},
+			"Start minimised on login":      {"Bei der Anmeldung minimiert starten"},
+			"Run as a systemd user service": {"Als systemd-Benutzerdienst ausführen"},
+			"Start hidden in the tray":      {"Versteckt im Infobereich starten"},
+			"Window":                        {"Fenster"},
+			"Compact window that hides when it loses focus": {"Kompaktes Fenster, das sich ohne Fokus ausblendet"},
+			"Takes effect on restart.":                      {"Wird nach einem Neustart übernommen."},
+			"Diagnostics":                                   {"Diagnose"},
+			"Refresh":                                       {"Aktualisieren"},
+			"Copy diagnostics bundle":                       {"Diagnosepaket kopieren"},
+			"Copied":                                        {"Kopiert"},
+			"Reaction":                                      {"Reaktion"},
+			"%s failed":                                     {"%s fehlgeschlagen"},
+			"Approve without a comment.":                    {"Ohne Kommentar genehmigen."},
+			"Requesting changes needs a comment.":           {"Für Änderungswünsche ist ein Kommentar nötig."},
+			"No hooks have run yet":                         {"Bisher wurden keine Hooks ausgeführt"},
+			"ok":                                            {"ok"},
+			"%d attempt":                                    {"%d Versuch", "%d Versuche"},
+			"No actions waiting to be sent":                 {"Keine Aktionen warten auf den Versand"},
+			"The config file could not be used, so the last working settings are in effect until it is fixed:": {"Die Konfigurationsdatei konnte nicht verwendet werden. Bis sie korrigiert ist, gelten die letzten funktionierenden Einstellungen:"},
+			"Replace the config file?": {"Konfigurationsdatei ersetzen?"},
+			"%s could not be read:\n%s\n\nSaving replaces it with these settings.": {"%s konnte nicht gelesen werden:\n%s\n\nBeim Speichern wird sie durch diese Einstellungen ersetzt."},
+			"@%s commented: %s":           {"@%s hat kommentiert: %s"},
+			"open":                        {"offen"},
+			"merged":                      {"zusammengeführt"},
+			"closed":                      {"geschlossen"},
+			"draft":                       {"Entwurf"},
+			"published":                   {"veröffentlicht"},
+			"prerelease":                  {"Vorabversion"},
+			"Details could not be loaded": {"Details konnten nicht geladen werden"},
+			"No token entered.":           {"Kein Token eingegeben."},
+			"The token for %s has the scopes [%s] but needs notifications (or repo) to read notifications. Add it at %s.":                                                     {"Das Token von %s hat die Berechtigungen [%s], braucht aber notifications (oder repo), um Benachrichtigungen zu lesen. Füge sie unter %s hinzu."},
+			"Without the repo scope, details from private repositories will not load.":                                                                                        {"Ohne die Berechtigung repo werden Details aus privaten Repositorys nicht geladen."},
+			"The token for %s cannot read notifications. Fine-grained tokens do not support the notifications API; use a classic token with the notifications scope from %s.": {"Das Token von %s kann keine Benachrichtigungen lesen. Fein abgestufte Tokens unterstützen die Benachrichtigungs-API nicht; verwende ein klassisches Token mit der Berechtigung notifications von %s."},
+			"GitHub rejected the token. It is invalid, revoked or expired.":                                                                                                   {"GitHub hat das Token abgelehnt. Es ist ungültig, widerrufen oder abgelaufen."},
+			"The token is not allowed to do this: %s":                                                                                                                         {"Das Token darf das nicht: %s"},
+			"%s: %s (since %s, restarts: %d)":                                                                                                                                 {"%s: %s (seit %s, Neustarts: %d)"},
+			"last error: %s":                                                                                                                                                  {"letzter Fehler: %s"},
+			"No background tasks":                                                                                                                                             {"Keine Hintergrundaufgaben"},
+			"running":                                                                                                                                                         {"läuft"},
+			"restarting":                                                                                                                                                      {"wird neu gestartet"},
+			"stopped":                                                                                                                                                         {"angehalten"},
+			"failed":                                                                                                                                                          {"fehlgeschlagen"},
+			"%[2]s failed for %[3]d of %[1]d notification:":                                                                                                                   {"%[2]s ist bei %[3]d von %[1]d Benachrichtigung fehlgeschlagen:", "%[2]s ist bei %[3]d von %[1]d Benachrichtigungen fehlgeschlagen:"},
+			"Light":                                {"Hell"},
+			"Dark":                                 {"Dunkel"},
+			"Default":                              {"Standard"},
+			"Blue":                                 {"Blau"},
+			"Green":                                {"Grün"},
+			"Orange":                               {"Orange"},
+			"Pink":                                 {"Rosa"},
+			"Purple":                               {"Lila"},
+			"Red":                                  {"Rot"},
+			"No poll yet":                          {"Noch keine Abfrage"},
+			"Last poll: %s, took %s":               {"Letzte Abfrage: %s, Dauer %s"},
+			"Result: failed: %s":                   {"Ergebnis: fehlgeschlagen: %s"},
+			"Result: %d notification":              {"Ergebnis: %d Benachrichtigung", "Ergebnis: %d Benachrichtigungen"},
+			"Rate limit: %d of %d left, resets %s": {"Ratenlimit: %d von %d übrig, zurückgesetzt um %s"},
+			"Next poll: %s":                        {"Nächste Abfrage: %s"},
+			"%s on %s/%s, %s":                      {"%s auf %s/%s, %s"},
+			"Locale: %s %s, log level: %s":         {"Sprache: %s %s, Log-Level: %s"},
+			"Config: %s":                           {"Konfiguration: %s"},
+			"Logs: %s":                             {"Logs: %s"},
+			"Interval: %s, adaptive: %s, focused: %s, server minimum: %s": {"Intervall: %s, adaptiv: %s, im Fokus: %s, Minimum des Servers: %s"},
+			"Notifications: %d listed, %d in total":                       {"Benachrichtigungen: %d angezeigt, %d insgesamt"},
+			"yes":                                                         {"ja"},
+			"no":                                                          {"nein"},
+			"must not be empty":                                           {"darf nicht leer sein"},
+			"must be a number of at least %d":                             {"muss eine Zahl von mindestens %d sein"},
+			"must be a time like 22:00":                                   {"muss eine Uhrzeit wie 22:00 sein"},
+			"invalid pattern %q":                                          {"ungültiges Muster %q"},
+			"The config file was not reloaded, the previous settings are kept:": {"Die Konfigurationsdatei wurde nicht neu geladen, die bisherigen Einstellungen bleiben aktiv:"},
+			"Starting on login could not be updated:":                           {"Der Start bei der Anmeldung konnte nicht geändert werden:"},
+			"%q is bound to both %s and %s":                                     {"%q ist sowohl %s als auch %s zugewiesen"},
+			"%q is not a color like #1e90ff":                                    {"%q ist keine Farbe wie #1e90ff"},
+			"%q is not a number":                                                {"%q ist keine Zahl"},
+			"%q is not a range like 22:00-08:00":                                {"%q ist kein Zeitraum wie 22:00-08:00"},
+			"%q is not a time like 08:00":                                       {"%q ist keine Uhrzeit wie 08:00"},
+			"%q is not a time like 22:00":                                       {"%q ist keine Uhrzeit wie 22:00"},
+			"%q is not one of %s":                                               {"%q ist keiner von %s"},
+			"%q is not one of %s or a color like #1e90ff":                       {"%q ist weder einer von %s noch eine Farbe wie #1e90ff"},
+			"%q is not system, light or dark":                                   {"%q ist weder system, light noch dark"},
+			"%q is not true or false":                                           {"%q ist weder true noch false"},
+			"%s returned %s":                                                    {"%s antwortete mit %s"},
+			"app is not running":                                                {"die App läuft nicht"},
+			"cannot reply to a %s":                                              {"auf %s kann nicht geantwortet werden"},
+			"hook %d (%s) has negative retries %d":                              {"Hook %d (%s) hat negative Wiederholungen %d"},
+			"hook %d (%s) has neither url nor command":                          {"Hook %d (%s) hat weder url noch command"},
+			"hook %d (%s) has the same name as another hook":                    {"Hook %d (%s) hat denselben Namen wie ein anderer Hook"},
+			"hook %d has no name":                                               {"Hook %d hat keinen Namen"},
+			"invalid key binding %q":                                            {"ungültige Tastenbelegung %q"},
+			"must be at least %d seconds, got %d":                               {"muss mindestens %d Sekunden sein, ist %d"},
+			"must be at least 1, got %d":                                        {"muss mindestens 1 sein, ist %d"},
+			"must be between 1 and %d, or 0 for one line, got %d":               {"muss zwischen 1 und %d liegen oder 0 für eine Zeile, ist %d"},
+			"must be between 50 and 300 percent, got %d":                        {"muss zwischen 50 und 300 Prozent liegen, ist %d"},
+			"no key for action %q":                                              {"keine Taste für die Aktion %q"},
+			"notification has no issue or pull request number":                  {"die Benachrichtigung hat keine Issue- oder Pull-Request-Nummer"},
+			"only pull requests can be reviewed":                                {"nur Pull Requests können geprüft werden"},
+			"requesting changes needs a comment":                                {"Änderungen anzufordern braucht einen Kommentar"},
+			"skipped, too many hooks queued, tried again on the next poll":      {"übersprungen, zu viele Hooks in der Warteschlange, wird beim nächsten Abruf erneut versucht"},
+			"starting on login is only supported on Linux; add the app to your login items instead": {"Start bei der Anmeldung wird nur unter Linux unterstützt; füge die App stattdessen zu deinen Anmeldeobjekten hinzu"},
+			"unknown action %q": {"unbekannte Aktion %q"},
+		},
+	},
+	"fr": {
+		Name:       "Français",
+		Plural:     pluralFrench,
+		DateLayout: map[string]string{"": "02/01/2006"},
+		TimeLayout: map[string]string{"": "15:04"},
+		Messages: map[string][]string{
+			"Loading...":                     {"Chargement…"},
+			"Add a GitHub token in Settings": {"Ajoutez un jeton GitHub dans les Paramètres"},
+			"Update it in Settings.":         {"Mettez-le à jour dans les Paramètres."},
+			"Failed to fetch notifications":  {"Impossible de récupérer les notifications"},
+			"No Notifications":               {"Aucune notification"},
+			"No New Notifications":           {"Aucune nouvelle notification"},
+			"You have %d new notification":   {"Vous avez %d nouvelle notification", "Vous avez %d nouvelles notifications"},
+			"Search notifications":           {"Rechercher dans les notifications"},
+			"Show":                           {"Afficher"},
+			"Hook Log":                       {"Journal des hooks"},
+			"Background Tasks":               {"Tâches de fond"},
+			"Outbox":                         {"Boîte d'envoi"},
+			"Quit":                           {"Quitter"},
+			"Close":                          {"Fermer"},
+			"Read":                           {"Lu"},
+			"Open":                           {"Ouvrir"},
+			"Done":                           {"Terminé"},
+			"Unsubscribe":                    {"Se désabonner"},
+			"Snooze":                         {"Reporter"},
+			"Clear":                          {"Désélectionner"},
+			"%d selected":                    {"%d sélectionnée", "%d sélectionnées"},
+			"Undo":                           {"Annuler"},
+			"Marked as read":                 {"Marquée comme lue"},
+			"Marked as done":                 {"Marquée comme terminée"},
+			"Unsubscribed":                   {"Désabonné"},
+			"Just now":                       {"À l'instant"},
+			"%d minute ago":                  {"il y a %d minute", "il y a %d minutes"},
+			"%d hour ago":                    {"il y a %d heure", "il y a %d heures"},
+			"%d day ago":                     {"il y a %d jour", "il y a %d jours"},
+			"State: %s":                      {"État : %s"},
+			"Author: %s":                     {"Auteur : %s"},
+			"Labels: %s":                     {"Étiquettes : %s"},
+			"Assignees: %s":                  {"Assignés : %s"},
+			"Reviewers: %s":                  {"Relecteurs : %s"},
+			"Recent comments":                {"Commentaires récents"},
+			"Failed to load comments":        {"Impossible de charger les commentaires"},
+			"No comments":                    {"Aucun commentaire"},
+			"Leave a comment":                {"Laisser un commentaire"},
+			"Comment":                        {"Commenter"},
+			"Approve":                        {"Approuver"},
+			"Request changes":                {"Demander des modifications"},
+			"Post comment?":                  {"Publier le commentaire ?"},
+			"Add reaction?":                  {"Ajouter la réaction ?"},
+			"Approve pull request?":          {"Approuver la pull request ?"},
+			"Request changes?":               {"Demander des modifications ?"},
+			"Settings":                       {"Paramètres"},
+			"Accounts":                       {"Comptes"},
+			"Polling":                        {"Interrogation"},
+			"Notifications":                  {"Notifications"},
+			"Filters":                        {"Filtres"},
+			"Appearance":                     {"Apparence"},
+			"Advanced":                       {"Avancé"},
+			"Name":                           {"Nom"},
+			"Token":                          {"Jeton"},
+			"Enter GitHub token":             {"Saisissez le jeton GitHub"},
+			"Test connection":                {"Tester la connexion"},
+			"Connecting...":                  {"Connexion…"},
+			"Checking token...":              {"Vérification du jeton…"},
+			"Interval (s)":                   {"Intervalle (s)"},
+			"Lookback (days)":                {"Historique (jours)"},
+			"Adaptive":                       {"Adaptatif"},
+			"Poll faster when active, slower when idle": {"Interroger plus souvent en activité, moins en veille"},
+			"Quiet hours":               {"Heures calmes"},
+			"Do not disturb":            {"Ne pas déranger"},
+			"From":                      {"De"},
+			"Until":                     {"À"},
+			"Hooks":                     {"Hooks"},
+			"Reasons":                   {"Raisons"},
+			"Repositories":              {"Dépôts"},
+			"Exclude":                   {"Exclure"},
+			"Theme":                     {"Thème"},
+			"Accent":                    {"Accent"},
+			"Text size":                 {"Taille du texte"},
+			"Title lines":               {"Lignes de titre"},
+			"Contrast":                  {"Contraste"},
+			"High contrast":             {"Contraste élevé"},
+			"List":                      {"Liste"},
+			"Show read notifications":   {"Afficher les notifications lues"},
+			"Language":                  {"Langue"},
+			"System":                    {"Système"},
+			"Keymap":                    {"Raccourcis"},
+			"Config file":               {"Fichier de configuration"},
+			"Save":                      {"Enregistrer"},
+			"Cancel":                    {"Annuler"},
+			"Could not check the token": {"Impossible de vérifier le jeton"},
+			"%s\n\nSave it anyway?":     {"%s\n\nL'enregistrer quand même ?"},
+			"GitHub token":              {"Jeton GitHub"},
+			"GitHub token expiring":     {"Le jeton GitHub expire bientôt"},
+			"Create a new one and update it in Settings.":    {"Créez-en un nouveau et mettez-le à jour dans les Paramètres."},
+			"The token expires today at %s.":                 {"Le jeton expire aujourd'hui à %s."},
+			"The token expires in %d day, on %s.":            {"Le jeton expire dans %d jour, le %s.", "Le jeton expire dans %d jours, le %s."},
+			"Connected as %s":                                {"Connecté en tant que %s"},
+			"Scopes: %s":                                     {"Portées : %s"},
+			"none listed (fine-grained token)":               {"aucune (jeton à granularité fine)"},
+			"Expires: %s":                                    {"Expire : %s"},
+			"Changing the language takes effect on restart.": {"Le changement de langue s'applique au redémarrage."},
+			"Startup":                       {"Démarrage"},
+			"Start minimised on login":      {"Démarrer réduit à la connexion"},
+			"Run as a systemd user service": {"Exécuter comme service utilisateur systemd"},
+			"Start hidden in the tray":      {"Démarrer caché dans la zone de notification"},
+			"Window":                        {"Fenêtre"},
+			"Compact window that hides when it loses focus": {"Fenêtre compacte masquée quand elle perd le focus"},
+			"Takes effect on restart.":                      {"S'applique au redémarrage."},
+			"Diagnostics":                                   {"Diagnostic"},
+			"Refresh":                                       {"Actualiser"},
+			"Copy diagnostics bundle":                       {"Copier le rapport de diagnostic"},
+			"Copied":                                        {"Copié"},
+			"Reaction":                                      {"Réaction"},
+			"%s failed":                                     {"Échec : %s"},
+			"Approve without a comment.":                    {"Approuver sans commentaire."},
+			"Requesting changes needs a comment.":           {"Une demande de modifications nécessite un commentaire."},
+			"No hooks have run yet":                         {"Aucun hook exécuté pour l’instant"},
+			"ok":                                            {"ok"},
+			"%d attempt":                                    {"%d tentative", "%d tentatives"},
+			"No actions waiting to be sent":                 {"Aucune action en attente d’envoi"},
+			"The config file could not be used, so the last working settings are in effect until it is fixed:": {"Le fichier de configuration est inutilisable ; les derniers réglages valides s’appliquent jusqu’à sa correction :"},
+			"Replace the config file?": {"Remplacer le fichier de configuration ?"},
+			"%s could not be read:\n%s\n\nSaving replaces it with these settings.": {"Impossible de lire %s :\n%s\n\nL’enregistrement le remplace par ces réglages."},
+			"@%s commented: %s":           {"@%s a commenté : %s"},
+			"open":                        {"ouvert"},
+			"merged":                      {"fusionné"},
+			"closed":                      {"fermé"},
+			"draft":                       {"brouillon"},
+			"published":                   {"publiée"},
+			"prerelease":                  {"préversion"},
+			"Details could not be loaded": {"Impossible de charger les détails"},
+			"No token entered.":           {"Aucun jeton saisi."},
+			"The token for %s has the scopes [%s] but needs notifications (or repo) to read notifications. Add it at %s.":                                                     {"Le jeton de %s a les portées [%s] mais il lui faut notifications (ou repo) pour lire les notifications. Ajoutez-la sur %s."},
+			"Without the repo scope, details from private repositories will not load.":                                                                                        {"Sans la portée repo, les détails des dépôts privés ne se chargeront pas."},
+			"The token for %s cannot read notifications. Fine-grained tokens do not support the notifications API; use a classic token with the notifications scope from %s.": {"Le jeton de %s ne peut pas lire les notifications. Les jetons à granularité fine ne prennent pas en charge l’API des notifications ; utilisez un jeton classique avec la portée notifications depuis %s."},
+			"GitHub rejected the token. It is invalid, revoked or expired.":                                                                                                   {"GitHub a refusé le jeton. Il est invalide, révoqué ou expiré."},
+			"The token is not allowed to do this: %s":                                                                                                                         {"Le jeton n’est pas autorisé à faire cela : %s"},
+			"%s: %s (since %s, restarts: %d)":                                                                                                                                 {"%s : %s (depuis %s, redémarrages : %d)"},
+			"last error: %s":                                                                                                                                                  {"dernière erreur : %s"},
+			"No background tasks":                                                                                                                                             {"Aucune tâche en arrière-plan"},
+			"running":                                                                                                                                                         {"en cours"},
+			"restarting":                                                                                                                                                      {"redémarrage"},
+			"stopped":                                                                                                                                                         {"arrêtée"},
+			"failed":                                                                                                                                                          {"en échec"},
+			"%[2]s failed for %[3]d of %[1]d notification:":                                                                                                                   {"%[2]s a échoué pour %[3]d notification sur %[1]d :", "%[2]s a échoué pour %[3]d notifications sur %[1]d :"},
+			"Light":                                {"Clair"},
+			"Dark":                                 {"Sombre"},
+			"Default":                              {"Par défaut"},
+			"Blue":                                 {"Bleu"},
+			"Green":                                {"Vert"},
+			"Orange":                               {"Orange"},
+			"Pink":                                 {"Rose"},
+			"Purple":                               {"Violet"},
+			"Red":                                  {"Rouge"},
+			"No poll yet":                          {"Aucune interrogation pour l’instant"},
+			"Last poll: %s, took %s":               {"Dernière interrogation : %s, durée %s"},
+			"Result: failed: %s":                   {"Résultat : échec : %s"},
+			"Result: %d notification":              {"Résultat : %d notification", "Résultat : %d notifications"},
+			"Rate limit: %d of %d left, resets %s": {"Limite de débit : %d sur %d restantes, réinitialisée à %s"},
+			"Next poll: %s":                        {"Prochaine interrogation : %s"},
+			"%s on %s/%s, %s":                      {"%s sur %s/%s, %s"},
+			"Locale: %s %s, log level: %s":         {"Langue : %s %s, niveau de journal : %s"},
+			"Config: %s":                           {"Configuration : %s"},
+			"Logs: %s":                             {"Journaux : %s"},
+			"Interval: %s, adaptive: %s, focused: %s, server minimum: %s": {"Intervalle : %s, adaptatif : %s, au premier plan : %s, minimum du serveur : %s"},
+			"Notifications: %d listed, %d in total":                       {"Notifications : %d affichées, %d au total"},
+			"yes":                                                         {"oui"},
+			"no":                                                          {"non"},
+			"must not be empty":                                           {"ne doit pas être vide"},
+			"must be a number of at least %d":                             {"doit être un nombre d’au moins %d"},
+			"must be a time like 22:00":                                   {"doit être une heure comme 22:00"},
+			"invalid pattern %q":                                          {"motif non valide %q"},
+			"The config file was not reloaded, the previous settings are kept:": {"Le fichier de configuration n’a pas été rechargé, les réglages précédents sont conservés :"},
+			"Starting on login could not be updated:":                           {"Le démarrage à l’ouverture de session n’a pas pu être mis à jour :"},
+			"%q is bound to both %s and %s":                                     {"%q est associé à la fois à %s et à %s"},
+			"%q is not a color like #1e90ff":                                    {"%q n'est pas une couleur comme #1e90ff"},
+			"%q is not a number":                                                {"%q n'est pas un nombre"},
+			"%q is not a range like 22:00-08:00":                                {"%q n'est pas une plage comme 22:00-08:00"},
+			"%q is not a time like 08:00":                                       {"%q n'est pas une heure comme 08:00"},
+			"%q is not a time like 22:00":                                       {"%q n'est pas une heure comme 22:00"},
+			"%q is not one of %s":                                               {"%q ne fait pas partie de %s"},
+			"%q is not one of %s or a color like #1e90ff":                       {"%q ne fait pas partie de %s et n'est pas une couleur comme #1e90ff"},
+			"%q is not system, light or dark":                                   {"%q n'est ni system, ni light, ni dark"},
+			"%q is not true or false":                                           {"%q n'est ni true ni false"},
+			"%s returned %s":                                                    {"%s a répondu %s"},
+			"app is not running":                                                {"l'application n'est pas lancée"},
+			"cannot reply to a %s":                                              {"impossible de répondre à %s"},
+			"hook %d (%s) has negative retries %d":                              {"le hook %d (%s) a un nombre de nouvelles tentatives négatif %d"},
+			"hook %d (%s) has neither url nor command":                          {"le hook %d (%s) n'a ni url ni command"},
+			"hook %d (%s) has the same name as another hook":                    {"le hook %d (%s) porte le même nom qu'un autre hook"},
+			"hook %d has no name":                                               {"le hook %d n'a pas de nom"},
+			"invalid key binding %q":                                            {"raccourci clavier %q invalide"},
+			"must be at least %d seconds, got %d":                               {"doit être d'au moins %d secondes, reçu %d"},
+			"must be at least 1, got %d":                                        {"doit être d'au moins 1, reçu %d"},
+			"must be between 1 and %d, or 0 for one line, got %d":               {"doit être entre 1 et %d, ou 0 pour une ligne, reçu %d"},
+			"must be between 50 and 300 percent, got %d":                        {"doit être entre 50 et 300 pour cent, reçu %d"},
+			"no key for action %q":                                              {"aucune touche pour l'action %q"},
+			"notification has no issue or pull request number":                  {"la notification n'a pas de numéro d'issue ou de pull request"},
+			"only pull requests can be reviewed":                                {"seules les pull requests peuvent être relues"},
+			"requesting changes needs a comment":                                {"demander des modifications nécessite un commentaire"},
+			"skipped, too many hooks queued, tried again on the next poll":      {"ignoré, trop de hooks en attente, réessayé à la prochaine vérification"},
+			"starting on login is only supported on Linux; add the app to your login items instead": {"le lancement à la connexion n'est pris en charge que sous Linux ; ajoutez plutôt l'application à vos éléments de connexion"},
+			"unknown action %q": {"action %q inconnue"},
+		},
+	},
+	"es": {
+		Name:       "Español",
+		Plural:     pluralOneOther,
+		DateLayout: map[string]string{"": "02/01/2006"},
+		TimeLayout: map[string]string{"": "15:04"},
+		Messages: map[string][]string{
+			"Loading...":                     {"Cargando…"},
+			"Add a GitHub token in Settings": {"Añade un token de GitHub en Ajustes"},
+			"Update it in Settings.":         {"Actualízalo en Ajustes."},
+			"Failed to fetch notifications":  {"No se pudieron obtener las notificaciones"},
+			"No Notifications":               {"No hay notificaciones"},
+			"No New Notifications":           {"No hay notificaciones nuevas"},
+			"You have %d new notification":   {"Tienes %d notificación nueva", "Tienes %d notificaciones nuevas"},
+			"Search notifications":           {"Buscar notificaciones"},
+			"Show":                           {"Mostrar"},
+			"Hook Log":                       {"Registro de hooks"},
+			"Background Tasks":               {"Tareas en segundo plano"},
+			"Outbox":                         {"Bandeja de salida"},
+			"Quit":                           {"Salir"},
+			"Close":                          {"Cerrar"},
+			"Read":                           {"Leída"},
+			"Open":                           {"Abrir"},
+			"Done":                           {"Hecho"},
+			"Unsubscribe":                    {"Cancelar suscripción"},
+			"Snooze":                         {"Posponer"},
+			"Clear":                          {"Deseleccionar"},
+			"%d selected":                    {"%d seleccionada", "%d seleccionadas"},
+			"Undo":                           {"Deshacer"},
+			"Marked as read":                 {"Marcada como leída"},
+			"Marked as done":                 {"Marcada como hecha"},
+			"Unsubscribed":                   {"Suscripción cancelada"},
+			"Just now":                       {"Ahora mismo"},
+			"%d minute ago":                  {"hace %d minuto", "hace %d minutos"},
+			"%d hour ago":                    {"hace %d hora", "hace %d horas"},
+			"%d day ago":                     {"hace %d día", "hace %d días"},
+			"State: %s":                      {"Estado: %s"},
+			"Author: %s":                     {"Autor: %s"},
+			"Labels: %s":                     {"Etiquetas: %s"},
+			"Assignees: %s":                  {"Asignados: %s"},
+			"Reviewers: %s":                  {"Revisores: %s"},
+			"Recent comments":                {"Comentarios recientes"},
+			"Failed to load comments":        {"No se pudieron cargar los comentarios"},
+			"No comments":                    {"Sin comentarios"},
+			"Leave a comment":                {"Deja un comentario"},
+			"Comment":                        {"Comentar"},
+			"Approve":                        {"Aprobar"},
+			"Request changes":                {"Solicitar cambios"},
+			"Post comment?":                  {"¿Publicar el comentario?"},
+			"Add reaction?":                  {"¿Añadir la reacción?"},
+			"Approve pull request?":          {"¿Aprobar la pull request?"},
+			"Request changes?":               {"¿Solicitar cambios?"},
+			"Settings":                       {"Ajustes"},
+			"Accounts":                       {"Cuentas"},
+			"Polling":                        {"Consulta"},
+			"Notifications":                  {"Notificaciones"},
+			"Filters":                        {"Filtros"},
+			"Appearance":                     {"Apariencia"},
+			"Advanced":                       {"Avanzado"},
+			"Name":                           {"Nombre"},
+			"Token":                          {"Token"},
+			"Enter GitHub token":             {"Introduce el token de GitHub"},
+			"Test connection":                {"Probar conexión"},
+			"Connecting...":                  {"Conectando…"},
+			"Checking token...":              {"Comprobando el token…"},
+			"Interval (s)":                   {"Intervalo (s)"},
+			"Lookback (days)":                {"Antigüedad (días)"},
+			"Adaptive":                       {"Adaptativo"},
+			"Poll faster when active, slower when idle": {"Consultar más a menudo con actividad y menos en reposo"},
+			"Quiet hours":               {"Horas de silencio"},
+			"Do not disturb":            {"No molestar"},
+			"From":                      {"Desde"},
+			"Until":                     {"Hasta"},
+			"Hooks":                     {"Hooks"},
+			"Reasons":                   {"Motivos"},
+			"Repositories":              {"Repositorios"},
+			"Exclude":                   {"Excluir"},
+			"Theme":                     {"Tema"},
+			"Accent":                    {"Acento"},
+			"Text size":                 {"Tamaño del texto"},
+			"Title lines":               {"Líneas del título"},
+			"Contrast":                  {"Contraste"},
+			"High contrast":             {"Alto contraste"},
+			"List":                      {"Lista"},
+			"Show read notifications":   {"Mostrar notificaciones leídas"},
+			"Language":                  {"Idioma"},
+			"System":                    {"Sistema"},
+			"Keymap":                    {"Atajos de teclado"},
+			"Config file":               {"Archivo de configuración"},
+			"Save":                      {"Guardar"},
+			"Cancel":                    {"Cancelar"},
+			"Could not check the token": {"No se pudo comprobar el token"},
+			"%s\n\nSave it anyway?":     {"%s\n\n¿Guardarlo de todos modos?"},
+			"GitHub token":              {"Token de GitHub"},
+			"GitHub token expiring":     {"El token de GitHub está a punto de caducar"},
+			"Create a new one and update it in Settings.":    {"Crea uno nuevo y actualízalo en Ajustes."},
+			"The token expires today at %s.":                 {"El token caduca hoy a las %s."},
+			"The token expires in %d day, on %s.":            {"El token caduca en %d día, el %s.", "El token caduca en %d días, el %s."},
+			"Connected as %s":                                {"Conectado como %s"},
+			"Scopes: %s":                                     {"Ámbitos: %s"},
+			"none listed (fine-grained token)":               {"ninguno (token detallado)"},
+			"Expires: %s":                                    {"Caduca: %s"},
+			"Changing the language takes effect on restart.": {"El cambio de idioma se aplica al reiniciar."},
+			"Startup":                       {"Inicio"},
+			"Start minimised on login":      {"Iniciar minimizado al iniciar sesión"},
+			"Run as a systemd user service": {"Ejecutar como servicio de usuario de systemd"},
+			"Start hidden in the tray":      {"Iniciar oculto en la bandeja del sistema"},
+			"Window":                        {"Ventana"},
+			"Compact window that hides when it loses focus": {"Ventana compacta que se oculta al perder el foco"},
+			"Takes effect on restart.":                      {"Se aplica al reiniciar."},
+			"Diagnostics":                                   {"Diagnóstico"},
+			"Refresh":                                       {"Actualizar"},
+			"Copy diagnostics bundle":                       {"Copiar paquete de diagnóstico"},
+			"Copied":                                        {"Copiado"},
+			"Reaction":                                      {"Reacción"},
+			"%s failed":                                     {"%s falló"},
+			"Approve without a comment.":                    {"Aprobar sin comentario."},
+			"Requesting changes needs a comment.":           {"Solicitar cambios requiere un comentario."},
+			"No hooks have run yet":                         {"Todavía no se ha ejecutado ningún hook"},
+			"ok":                                            {"ok"},
+			"%d attempt":                                    {"%d intento", "%d intentos"},
+			"No actions waiting to be sent":                 {"No hay acciones pendientes de envío"},
+			"The config file could not be used, so the last working settings are in effect until it is fixed:": {"No se pudo usar el archivo de configuración; se aplican los últimos ajustes válidos hasta que se corrija:"},
+			"Replace the config file?": {"¿Reemplazar el archivo de configuración?"},
+			"%s could not be read:\n%s\n\nSaving replaces it with these settings.": {"No se pudo leer %s:\n%s\n\nAl guardar se reemplaza con estos ajustes."},
+			"@%s commented: %s":           {"@%s comentó: %s"},
+			"open":                        {"abierto"},
+			"merged":                      {"fusionado"},
+			"closed":                      {"cerrado"},
+			"draft":                       {"borrador"},
+			"published":                   {"publicada"},
+			"prerelease":                  {"preliminar"},
+			"Details could not be loaded": {"No se pudieron cargar los detalles"},
+			"No token entered.":           {"No se ha introducido ningún token."},
+			"The token for %s has the scopes [%s] but needs notifications (or repo) to read notifications. Add it at %s.":                                                     {"El token de %s tiene los permisos [%s] pero necesita notifications (o repo) para leer notificaciones. Añádelo en %s."},
+			"Without the repo scope, details from private repositories will not load.":                                                                                        {"Sin el permiso repo no se cargarán los detalles de repositorios privados."},
+			"The token for %s cannot read notifications. Fine-grained tokens do not support the notifications API; use a classic token with the notifications scope from %s.": {"El token de %s no puede leer notificaciones. Los tokens detallados no admiten la API de notificaciones; usa un token clásico con el permiso notifications desde %s."},
+			"GitHub rejected the token. It is invalid, revoked or expired.":                                                                                                   {"GitHub rechazó el token. No es válido, se revocó o caducó."},
+			"The token is not allowed to do this: %s":                                                                                                                         {"El token no tiene permiso para esto: %s"},
+			"%s: %s (since %s, restarts: %d)":                                                                                                                                 {"%s: %s (desde %s, reinicios: %d)"},
+			"last error: %s":                                                                                                                                                  {"último error: %s"},
+			"No background tasks":                                                                                                                                             {"No hay tareas en segundo plano"},
+			"running":                                                                                                                                                         {"en ejecución"},
+			"restarting":                                                                                                                                                      {"reiniciando"},
+			"stopped":                                                                                                                                                         {"detenida"},
+			"failed":                                                                                                                                                          {"fallida"},
+			"%[2]s failed for %[3]d of %[1]d notification:":                                                                                                                   {"%[2]s falló en %[3]d de %[1]d notificación:", "%[2]s falló en %[3]d de %[1]d notificaciones:"},
+			"Light":                                {"Claro"},
+			"Dark":                                 {"Oscuro"},
+			"Default":                              {"Predeterminado"},
+			"Blue":                                 {"Azul"},
+			"Green":                                {"Verde"},
+			"Orange":                               {"Naranja"},
+			"Pink":                                 {"Rosa"},
+			"Purple":                               {"Morado"},
+			"Red":                                  {"Rojo"},
+			"No poll yet":                          {"Aún no se ha consultado"},
+			"Last poll: %s, took %s":               {"Última consulta: %s, duró %s"},
+			"Result: failed: %s":                   {"Resultado: error: %s"},
+			"Result: %d notification":              {"Resultado: %d notificación", "Resultado: %d notificaciones"},
+			"Rate limit: %d of %d left, resets %s": {"Límite de peticiones: quedan %d de %d, se restablece a las %s"},
+			"Next poll: %s":                        {"Próxima consulta: %s"},
+			"%s on %s/%s, %s":                      {"%s en %s/%s, %s"},
+			"Locale: %s %s, log level: %s":         {"Idioma: %s %s, nivel de registro: %s"},
+			"Config: %s":                           {"Configuración: %s"},
+			"Logs: %s":                             {"Registros: %s"},
+			"Interval: %s, adaptive: %s, focused: %s, server minimum: %s": {"Intervalo: %s, adaptativo: %s, en primer plano: %s, mínimo del servidor: %s"},
+			"Notifications: %d listed, %d in total":                       {"Notificaciones: %d mostradas, %d en total"},
+			"yes":                                                         {"sí"},
+			"no":                                                          {"no"},
+			"must not be empty":                                           {"no puede estar vacío"},
+			"must be a number of at least %d":                             {"debe ser un número de al menos %d"},
+			"must be a time like 22:00":                                   {"debe ser una hora como 22:00"},
+			"invalid pattern %q":                                          {"patrón no válido %q"},
+			"The config file was not reloaded, the previous settings are kept:": {"El archivo de configuración no se ha recargado, se mantienen los ajustes anteriores:"},
+			"Starting on login could not be updated:":                           {"No se pudo actualizar el inicio al iniciar sesión:"},
+			"%q is bound to both %s and %s":                                     {"%q está asignada a %s y a %s"},
+			"%q is not a color like #1e90ff":                                    {"%q no es un color como #1e90ff"},
+			"%q is not a number":                                                {"%q no es un número"},
+			"%q is not a range like 22:00-08:00":                                {"%q no es un intervalo como 22:00-08:00"},
+			"%q is not a time like 08:00":                                       {"%q no es una hora como 08:00"},
+			"%q is not a time like 22:00":                                       {"%q no es una hora como 22:00"},
+			"%q is not one of %s":                                               {"%q no es uno de %s"},
+			"%q is not one of %s or a color like #1e90ff":                       {"%q no es uno de %s ni un color como #1e90ff"},
+			"%q is not system, light or dark":                                   {"%q no es system, light ni dark"},
+			"%q is not true or false":                                           {"%q no es true ni false"},
+			"%s returned %s":                                                    {"%s respondió %s"},
+			"app is not running":                                                {"la aplicación no se está ejecutando"},
+			"cannot reply to a %s":                                              {"no se puede responder a %s"},
+			"hook %d (%s) has negative retries %d":                              {"el hook %d (%s) tiene reintentos negativos %d"},
+			"hook %d (%s) has neither url nor command":                          {"el hook %d (%s) no tiene url ni command"},
+			"hook %d (%s) has the same name as another hook":                    {"el hook %d (%s) tiene el mismo nombre que otro hook"},
+			"hook %d has no name":                                               {"el hook %d no tiene nombre"},
+			"invalid key binding %q":                                            {"atajo de teclado %q no válido"},
+			"must be at least %d seconds, got %d":                               {"debe ser de al menos %d segundos, se indicó %d"},
+			"must be at least 1, got %d":                                        {"debe ser al menos 1, se indicó %d"},
+			"must be between 1 and %d, or 0 for one line, got %d":               {"debe estar entre 1 y %d, o 0 para una línea, se indicó %d"},
+			"must be between 50 and 300 percent, got %d":                        {"debe estar entre el 50 y el 300 por ciento, se indicó %d"},
+			"no key for action %q":                                              {"no hay tecla para la acción %q"},
+			"notification has no issue or pull request number":                  {"la notificación no tiene número de issue ni de pull request"},
+			"only pull requests can be reviewed":                                {"solo se pueden revisar pull requests"},
+			"requesting changes needs a comment":                                {"solicitar cambios requiere un comentario"},
+			"skipped, too many hooks queued, tried again on the next poll":      {"omitido, demasiados hooks en cola, se reintentará en la próxima consulta"},
+			"starting on login is only supported on Linux; add the app to your login items instead": {"el inicio al iniciar sesión solo es compatible con Linux; añade la aplicación a tus elementos de inicio de sesión"},
+			"unknown action %q": {"acción %q desconocida"},
+		},
+	},
+	"ja": {
+		Name:       "日本語",
+		Plural:     pluralNone,
+		DateLayout: map[string]string{"": "2006/01/02"},
+		TimeLayout: map[string]string{"": "15:04"},
+		Messages: map[string][]string{
+			"Loading...":                     {"読み込み中…"},
+			"Add a GitHub token in Settings": {"設定で GitHub トークンを追加してください"},
+			"Update it in Settings.":         {"設定で更新してください。"},
+			"Failed to fetch notifications":  {"通知を取得できませんでした"},
+			"No Notifications":               {"通知はありません"},
+			"No New Notifications":           {"新しい通知はありません"},
+			"You have %d new notification":   {"新しい通知が %d 件あります"},
+			"Search notifications":           {"通知を検索"},
+			"Show":                           {"表示"},
+			"Hook Log":                       {"フックのログ"},
+			"Background Tasks":               {"バックグラウンドタスク"},
+			"Outbox":                         {"送信待ち"},
+			"Quit":                           {"終了"},
+			"Close":                          {"閉じる"},
+			"Read":                           {"既読"},
+			"Open":                           {"開く"},
+			"Done":                           {"完了"},
+			"Unsubscribe":                    {"購読解除"},
+			"Snooze":                         {"スヌーズ"},
+			"Clear":                          {"選択解除"},
+			"%d selected":                    {"%d 件選択中"},
+			"Undo":                           {"元に戻す"},
+			"Marked as read":                 {"既読にしました"},
+			"Marked as done":                 {"完了にしました"},
+			"Unsubscribed":                   {"購読を解除しました"},
+			"Just now":                       {"たった今"},
+			"%d minute ago":                  {"%d 分前"},
+			"%d hour ago":                    {"%d 時間前"},
+			"%d day ago":                     {"%d 日前"},
+			"State: %s":                      {"状態: %s"},
+			"Author: %s":                     {"作成者: %s"},
+			"Labels: %s":                     {"ラベル: %s"},
+			"Assignees: %s":                  {"担当者: %s"},
+			"Reviewers: %s":                  {"レビュアー: %s"},
+			"Recent comments":                {"最近のコメント"},
+			"Failed to load comments":        {"コメントを読み込めませんでした"},
+			"No comments":                    {"コメントはありません"},
+			"Leave a comment":                {"コメントを書く"},
+			"Comment":                        {"コメント"},
+			"Approve":                        {"承認"},
+			"Request changes":                {"変更を依頼"},
+			"Post comment?":                  {"コメントを投稿しますか？"},
+			"Add reaction?":                  {"リアクションを追加しますか？"},
+			"Approve pull request?":          {"プルリクエストを承認しますか？"},
+			"Request changes?":               {"変更を依頼しますか？"},
+			"Settings":                       {"設定"},
+			"Accounts":                       {"アカウント"},
+			"Polling":                        {"ポーリング"},
+			"Notifications":                  {"通知"},
+			"Filters":                        {"フィルター"},
+			"Appearance":                     {"外観"},
+			"Advanced":                       {"詳細"},
+			"Name":                           {"名前"},
+			"Token":                          {"トークン"},
+			"Enter GitHub token":             {"GitHub トークンを入力"},
+			"Test connection":                {"接続テスト"},
+			"Connecting...":                  {"接続中…"},
+			"Checking token...":              {"トークンを確認中…"},
+			"Interval (s)":                   {"間隔 (秒)"},
+			"Lookback (days)":                {"取得期間 (日)"},
+			"Adaptive":                       {"自動調整"},
+			"Poll faster when active, slower when idle": {"操作中は頻繁に、アイドル時は間隔を空けて確認する"},
+			"Quiet hours":               {"通知停止時間"},
+			"Do not disturb":            {"おやすみモード"},
+			"From":                      {"開始"},
+			"Until":                     {"終了"},
+			"Hooks":                     {"フック"},
+			"Reasons":                   {"理由"},
+			"Repositories":              {"リポジトリ"},
+			"Exclude":                   {"除外"},
+			"Theme":                     {"テーマ"},
+			"Accent":                    {"アクセント"},
+			"Text size":                 {"文字サイズ"},
+			"Title lines":               {"タイトルの行数"},
+			"Contrast":                  {"コントラスト"},
+			"High contrast":             {"ハイコントラスト"},
+			"List":                      {"一覧"},
+			"Show read notifications":   {"既読の通知を表示"},
+			"Language":                  {"言語"},
+			"System":                    {"システム"},
+			"Keymap":                    {"キー割り当て"},
+			"Config file":               {"設定ファイル"},
+			"Save":                      {"保存"},
+			"Cancel":                    {"キャンセル"},
+			"Could not check the token": {"トークンを確認できませんでした"},
+			"%s\n\nSave it anyway?":     {"%s\n\nこのまま保存しますか？"},
+			"GitHub token":              {"GitHub トークン"},
+			"GitHub token expiring":     {"GitHub トークンの有効期限が近づいています"},
+			"Create a new one and update it in Settings.":    {"新しいトークンを作成し、設定で更新してください。"},
+			"The token expires today at %s.":                 {"トークンは本日 %s に期限切れになります。"},
+			"The token expires in %d day, on %s.":            {"トークンはあと %d 日、%s に期限切れになります。"},
+			"Connected as %s":                                {"%s として接続済み"},
+			"Scopes: %s":                                     {"スコープ: %s"},
+			"none listed (fine-grained token)":               {"なし (fine-grained トークン)"},
+			"Expires: %s":                                    {"有効期限: %s"},
+			"Changing the language takes effect on restart.": {"言語の変更は再起動後に反映されます。"},
+			"Startup":                       {"起動"},
+			"Start minimised on login":      {"ログイン時に最小化して起動"},
+			"Run as a systemd user service": {"systemd ユーザーサービスとして実行"},
+			"Start hidden in the tray":      {"トレイに隠した状態で起動"},
+			"Window":                        {"ウィンドウ"},
+			"Compact window that hides when it loses focus": {"フォーカスを失うと隠れるコンパクトなウィンドウ"},
+			"Takes effect on restart.":                      {"再起動後に反映されます。"},
+			"Diagnostics":                                   {"診断"},
+			"Refresh":                                       {"更新"},
+			"Copy diagnostics bundle":                       {"診断情報をコピー"},
+			"Copied":                                        {"コピーしました"},
+			"Reaction":                                      {"リアクション"},
+			"%s failed":                                     {"%sに失敗しました"},
+			"Approve without a comment.":                    {"コメントなしで承認します。"},
+			"Requesting changes needs a comment.":           {"変更をリクエストするにはコメントが必要です。"},
+			"No hooks have run yet":                         {"まだ実行されたフックはありません"},
+			"ok":                                            {"成功"},
+			"%d attempt":                                    {"%d 回試行"},
+			"No actions waiting to be sent":                 {"送信待ちの操作はありません"},
+			"The config file could not be used, so the last working settings are in effect until it is fixed:": {"設定ファイルを使用できないため、修正されるまで最後に有効だった設定を使用します:"},
+			"Replace the config file?": {"設定ファイルを置き換えますか?"},
+			"%s could not be read:\n%s\n\nSaving replaces it with these settings.": {"%s を読み込めませんでした:\n%s\n\n保存すると、この設定で置き換えられます。"},
+			"@%s commented: %s":           {"@%s がコメント: %s"},
+			"open":                        {"オープン"},
+			"merged":                      {"マージ済み"},
+			"closed":                      {"クローズ"},
+			"draft":                       {"ドラフト"},
+			"published":                   {"公開済み"},
+			"prerelease":                  {"プレリリース"},
+			"Details could not be loaded": {"詳細を読み込めませんでした"},
+			"No token entered.":           {"トークンが入力されていません。"},
+			"The token for %s has the scopes [%s] but needs notifications (or repo) to read notifications. Add it at %s.":                                                     {"%s のトークンのスコープは [%s] ですが、通知を読むには notifications (または repo) が必要です。%s で追加してください。"},
+			"Without the repo scope, details from private repositories will not load.":                                                                                        {"repo スコープがないと、プライベートリポジトリの詳細は読み込まれません。"},
+			"The token for %s cannot read notifications. Fine-grained tokens do not support the notifications API; use a classic token with the notifications scope from %s.": {"%s のトークンは通知を読めません。Fine-grained トークンは通知 API に対応していません。%s から notifications スコープ付きのクラシックトークンを使用してください。"},
+			"GitHub rejected the token. It is invalid, revoked or expired.":                                                                                                   {"GitHub がトークンを拒否しました。無効、取り消し済み、または期限切れです。"},
+			"The token is not allowed to do this: %s":                                                                                                                         {"このトークンには実行権限がありません: %s"},
+			"%s: %s (since %s, restarts: %d)":                                                                                                                                 {"%s: %s (%s から、再起動: %d 回)"},
+			"last error: %s":                                                                                                                                                  {"最後のエラー: %s"},
+			"No background tasks":                                                                                                                                             {"バックグラウンドタスクはありません"},
+			"running":                                                                                                                                                         {"実行中"},
+			"restarting":                                                                                                                                                      {"再起動中"},
+			"stopped":                                                                                                                                                         {"停止"},
+			"failed":                                                                                                                                                          {"失敗"},
+			"%[2]s failed for %[3]d of %[1]d notification:":                                                                                                                   {"%[1]d 件中 %[3]d 件の通知で %[2]s に失敗しました:"},
+			"Light":                                {"ライト"},
+			"Dark":                                 {"ダーク"},
+			"Default":                              {"デフォルト"},
+			"Blue":                                 {"青"},
+			"Green":                                {"緑"},
+			"Orange":                               {"オレンジ"},
+			"Pink":                                 {"ピンク"},
+			"Purple":                               {"紫"},
+			"Red":                                  {"赤"},
+			"No poll yet":                          {"まだ取得していません"},
+			"Last poll: %s, took %s":               {"最終取得: %s、所要時間 %s"},
+			"Result: failed: %s":                   {"結果: 失敗: %s"},
+			"Result: %d notification":              {"結果: 通知 %d 件"},
+			"Rate limit: %d of %d left, resets %s": {"レート制限: 残り %d / %d、%s にリセット"},
+			"Next poll: %s":                        {"次回の取得: %s"},
+			"%s on %s/%s, %s":                      {"%s (%s/%s、%s)"},
+			"Locale: %s %s, log level: %s":         {"言語: %s %s、ログレベル: %s"},
+			"Config: %s":                           {"設定: %s"},
+			"Logs: %s":                             {"ログ: %s"},
+			"Interval: %s, adaptive: %s, focused: %s, server minimum: %s": {"間隔: %s、自動調整: %s、フォーカス: %s、サーバーの最小値: %s"},
+			"Notifications: %d listed, %d in total":                       {"通知: 表示 %d 件、合計 %d 件"},
+			"yes":                                                         {"はい"},
+			"no":                                                          {"いいえ"},
+			"must not be empty":                                           {"空にできません"},
+			"must be a number of at least %d":                             {"%d 以上の数値を入力してください"},
+			"must be a time like 22:00":                                   {"22:00 のような時刻を入力してください"},
+			"invalid pattern %q":                                          {"無効なパターン %q"},
+			"The config file was not reloaded, the previous settings are kept:": {"設定ファイルを再読み込みできなかったため、以前の設定を使い続けます:"},
+			"Starting on login could not be updated:":                           {"ログイン時の起動を更新できませんでした:"},
+			"%q is bound to both %s and %s":                                     {"%q は %s と %s の両方に割り当てられています"},
+			"%q is not a color like #1e90ff":                                    {"%q は #1e90ff のような色ではありません"},
+			"%q is not a number":                                                {"%q は数値ではありません"},
+			"%q is not a range like 22:00-08:00":                                {"%q は 22:00-08:00 のような範囲ではありません"},
+			"%q is not a time like 08:00":                                       {"%q は 08:00 のような時刻ではありません"},
+			"%q is not a time like 22:00":                                       {"%q は 22:00 のような時刻ではありません"},
+			"%q is not one of %s":                                               {"%q は %s のいずれでもありません"},
+			"%q is not one of %s or a color like #1e90ff":                       {"%q は %s のいずれでも #1e90ff のような色でもありません"},
+			"%q is not system, light or dark":                                   {"%q は system、light、dark のいずれでもありません"},
+			"%q is not true or false":                                           {"%q は true でも false でもありません"},
+			"%s returned %s":                                                    {"%s が %s を返しました"},
+			"app is not running":                                                {"アプリが起動していません"},
+			"cannot reply to a %s":                                              {"%s には返信できません"},
+			"hook %d (%s) has negative retries %d":                              {"フック %d (%s) の再試行回数 %d が負です"},
+			"hook %d (%s) has neither url nor command":                          {"フック %d (%s) に url も command もありません"},
+			"hook %d (%s) has the same name as another hook":                    {"フック %d (%s) は別のフックと同じ名前です"},
+			"hook %d has no name":                                               {"フック %d に名前がありません"},
+			"invalid key binding %q":                                            {"無効なキー割り当て %q"},
+			"must be at least %d seconds, got %d":                               {"%d 秒以上である必要があります（指定値 %d）"},
+			"must be at least 1, got %d":                                        {"1 以上である必要があります（指定値 %d）"},
+			"must be between 1 and %d, or 0 for one line, got %d":               {"1 から %d の間、または 1 行の場合は 0 である必要があります（指定値 %d）"},
+			"must be between 50 and 300 percent, got %d":                        {"50 から 300 パーセントの間である必要があります（指定値 %d）"},
+			"no key for action %q":                                              {"操作 %q にキーがありません"},
+			"notification has no issue or pull request number":                  {"通知に Issue またはプルリクエストの番号がありません"},
+			"only pull requests can be reviewed":                                {"レビューできるのはプルリクエストだけです"},
+			"requesting changes needs a comment":                                {"変更を依頼するにはコメントが必要です"},
+			"skipped, too many hooks queued, tried again on the next poll":      {"スキップしました。待機中のフックが多すぎるため、次回の取得時に再試行します"},
+			"starting on login is only supported on Linux; add the app to your login items instead": {"ログイン時の起動は Linux でのみ対応しています。代わりにアプリをログイン項目に追加してください"},
+			"unknown action %q": {"不明な操作 %q"},
+		},
+	},
+}
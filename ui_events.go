@@ -0,0 +1,136 @@
+package main
+
+import (
+	"sync"
+
+	"fyne.io/fyne/v2"
+)
+
+// uiEvents serialises every widget update coming from background goroutines
+// (polling, image loading, hooks) onto a single goroutine, so they never
+// race with each other. The queue is unbounded, so runOnUI never blocks,
+// not even when called from an event.
+var uiEvents = struct {
+	sync.Mutex
+	queue []func()
+	wake  chan struct{}
+}{
+	wake: make(chan struct{}, 1),
+}
+
+// uiState is shared between Fyne callbacks and the UI event loop, which run
+// on different goroutines.
+var uiState = struct {
+	sync.Mutex
+	windowVisible        bool
+	detailNotificationID string
+	snackbarActionID     int
+	settingsWindow       fyne.Window
+	detailWidenedFrom    float32
+	detailWidenedTo      float32
+}{}
+
+func startUIEventLoop() {
+	go func() {
+		for range uiEvents.wake {
+			for {
+				uiEvents.Lock()
+				events := uiEvents.queue
+				uiEvents.queue = nil
+				uiEvents.Unlock()
+
+				if len(events) == 0 {
+					break
+				}
+
+				for _, event := range events {
+					event()
+				}
+			}
+		}
+	}()
+}
+
+func runOnUI(event func()) {
+	uiEvents.Lock()
+	uiEvents.queue = append(uiEvents.queue, event)
+	uiEvents.Unlock()
+
+	select {
+	case uiEvents.wake <- struct{}{}:
+	default:
+	}
+}
+
+func isWindowVisible() bool {
+	uiState.Lock()
+	defer uiState.Unlock()
+
+	return uiState.windowVisible
+}
+
+func setWindowVisible(visible bool) {
+	uiState.Lock()
+	defer uiState.Unlock()
+
+	uiState.windowVisible = visible
+}
+
+func currentDetailNotificationID() string {
+	uiState.Lock()
+	defer uiState.Unlock()
+
+	return uiState.detailNotificationID
+}
+
+func setDetailNotificationID(id string) {
+	uiState.Lock()
+	defer uiState.Unlock()
+
+	uiState.detailNotificationID = id
+}
+
+func currentSnackbarActionID() int {
+	uiState.Lock()
+	defer uiState.Unlock()
+
+	return uiState.snackbarActionID
+}
+
+func setSnackbarActionID(id int) {
+	uiState.Lock()
+	defer uiState.Unlock()
+
+	uiState.snackbarActionID = id
+}
+
+func currentSettingsWindow() fyne.Window {
+	uiState.Lock()
+	defer uiState.Unlock()
+
+	return uiState.settingsWindow
+}
+
+func setSettingsWindow(w fyne.Window) {
+	uiState.Lock()
+	defer uiState.Unlock()
+
+	uiState.settingsWindow = w
+}
+
+// detailWidening returns the width the window had before the detail pane
+// widened it and the width it was widened to, or zeros.
+func detailWidening() (float32, float32) {
+	uiState.Lock()
+	defer uiState.Unlock()
+
+	return uiState.detailWidenedFrom, uiState.detailWidenedTo
+}
+
+func setDetailWidening(from float32, to float32) {
+	uiState.Lock()
+	defer uiState.Unlock()
+
+	uiState.detailWidenedFrom = from
+	uiState.detailWidenedTo = to
+}
@@ -0,0 +1,222 @@
+package main
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRunOnUIDoesNotBlock(t *testing.T) {
+	const senders = 8
+	const events = 500
+
+	var ran atomic.Int64
+	var wg sync.WaitGroup
+
+	// far more than any buffer, queued from goroutines and from events
+	for i := 0; i < senders; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for j := 0; j < events; j++ {
+				runOnUI(func() {
+					ran.Add(1)
+					runOnUI(func() { ran.Add(1) })
+				})
+			}
+		}()
+	}
+
+	queued := make(chan struct{})
+
+	go func() {
+		wg.Wait()
+		close(queued)
+	}()
+
+	select {
+	case <-queued:
+	case <-time.After(5 * time.Second):
+		t.Fatal("runOnUI blocked")
+	}
+
+	eventually(t, 10*time.Second, func() bool {
+		return ran.Load() == 2*senders*events
+	})
+}
+
+func TestRunOnUIKeepsOrder(t *testing.T) {
+	var order []int
+
+	for i := 0; i < 100; i++ {
+		i := i
+		runOnUI(func() { order = append(order, i) })
+	}
+
+	waitUI(t)
+
+	for i, got := range order {
+		if got != i {
+			t.Fatalf("event %d ran as %d", got, i)
+		}
+	}
+}
+
+// TestNotifyLoopRace polls a fake API while Fyne callbacks read and write
+// the state the UI event loop uses. Run it with -race.
+func TestNotifyLoopRace(t *testing.T) {
+	fake := useFakeGitHub(t, 20)
+
+	startNotifyLoop()
+
+	eventually(t, 10*time.Second, func() bool {
+		return notificationStore.Len() == 20
+	})
+
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+
+	callback := func(f func()) {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+					f()
+					time.Sleep(time.Millisecond)
+				}
+			}
+		}()
+	}
+
+	callback(refreshNotifications)
+	callback(func() {
+		setWindowVisible(!isWindowVisible())
+	})
+	callback(func() {
+		runOnUI(toggleWindow)
+	})
+	callback(func() {
+		_ = currentDetailNotificationID()
+		_ = currentSnackbarActionID()
+		_ = currentSettingsWindow()
+	})
+	callback(func() {
+		runOnUI(func() {
+			if notification := notificationStore.At(0); notification != nil {
+				showNotificationDetail(notification)
+			}
+		})
+	})
+	callback(func() {
+		runOnUI(notificationListComponent.Refresh)
+	})
+
+	time.Sleep(500 * time.Millisecond)
+	close(stop)
+	wg.Wait()
+	waitUI(t)
+
+	if fake.requestCount("GET /notifications") < 2 {
+		t.Errorf("refresh did not poll again: %d polls", fake.requestCount("GET /notifications"))
+	}
+}
+
+func TestActionsRace(t *testing.T) {
+	useFakeGitHub(t, 5)
+
+	startNotifyLoop()
+
+	eventually(t, 10*time.Second, func() bool {
+		return notificationStore.Len() == 5
+	})
+
+	runOnUI(func() {
+		readNotificationAction(notificationStore.At(0))
+	})
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			if id := currentSnackbarActionID(); id != 0 {
+				runOnUI(func() { undoAction(id) })
+			}
+
+			refreshNotifications()
+		}()
+	}
+
+	wg.Wait()
+	waitUI(t)
+}
+
+func TestKeymapRace(t *testing.T) {
+	useFakeGitHub(t, 0)
+
+	t.Cleanup(func() {
+		appConfig.Lock()
+		appConfig.effective.Keymap = nil
+		appConfig.Unlock()
+
+		runOnUI(loadKeymap)
+		waitUI(t)
+	})
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+
+	// Fyne's key callbacks, with a key bound to nothing
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+
+		for {
+			select {
+			case <-done:
+				return
+			default:
+				handleKey("z")
+			}
+		}
+	}()
+
+	for i := 0; i < 50; i++ {
+		key := "R"
+
+		if i%2 == 0 {
+			key = "F5"
+		}
+
+		appConfig.Lock()
+		appConfig.effective.Keymap = map[string]string{"refresh": key}
+		appConfig.Unlock()
+
+		runOnUI(loadKeymap)
+	}
+
+	waitUI(t)
+	close(done)
+	wg.Wait()
+
+	keymap.Lock()
+	action := keymap.actions["R"]
+	keymap.Unlock()
+
+	if action != "refresh" {
+		t.Errorf("R runs %q after the last config change, want refresh", action)
+	}
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v55/github"
+)
+
+const SNOOZE_DURATION time.Duration = time.Hour
+
+// viewFilter decides which fetched notifications are listed: snoozed ones
+// are hidden until their snooze ends, and the search query narrows the rest.
+var viewFilter = struct {
+	sync.Mutex
+	query   string
+	snoozes map[string]time.Time
+}{
+	snoozes: make(map[string]time.Time),
+}
+
+func loadSnoozes() {
+	snoozesJSON := notifierApp.Preferences().String("snoozed_notifications")
+
+	if snoozesJSON == "" {
+		return
+	}
+
+	var snoozes map[string]time.Time
+
+	if err := json.Unmarshal([]byte(snoozesJSON), &snoozes); err != nil {
+		slog.Warn("Invalid snoozed notifications", "err", err)
+		return
+	}
+
+	viewFilter.Lock()
+	viewFilter.snoozes = snoozes
+	viewFilter.Unlock()
+}
+
+// saveSnoozes must be called with viewFilter locked.
+func saveSnoozes() {
+	for id, until := range viewFilter.snoozes {
+		if time.Now().After(until) {
+			delete(viewFilter.snoozes, id)
+		}
+	}
+
+	snoozesJSON, err := json.Marshal(viewFilter.snoozes)
+
+	if err != nil {
+		slog.Error("Snoozes not saved", "err", err)
+		return
+	}
+
+	notifierApp.Preferences().SetString("snoozed_notifications", string(snoozesJSON))
+}
+
+func snoozeNotification(notification *github.Notification, duration time.Duration) {
+	viewFilter.Lock()
+	viewFilter.snoozes[notification.GetID()] = time.Now().Add(duration)
+	saveSnoozes()
+	viewFilter.Unlock()
+
+	notificationStore.Refilter()
+}
+
+func unsnoozeNotification(notification *github.Notification) {
+	viewFilter.Lock()
+	delete(viewFilter.snoozes, notification.GetID())
+	saveSnoozes()
+	viewFilter.Unlock()
+
+	notificationStore.Refilter()
+}
+
+func setSearchQuery(query string) {
+	viewFilter.Lock()
+	viewFilter.query = strings.ToLower(strings.TrimSpace(query))
+	viewFilter.Unlock()
+
+	notificationStore.Refilter()
+}
+
+func notificationFilter(notification *github.Notification) bool {
+	viewFilter.Lock()
+	defer viewFilter.Unlock()
+
+	if until, ok := viewFilter.snoozes[notification.GetID()]; ok && time.Now().Before(until) {
+		return false
+	}
+
+	if !matchesConfigFilters(notification.GetRepository().GetFullName(), notification.GetReason()) {
+		return false
+	}
+
+	if viewFilter.query == "" {
+		return true
+	}
+
+	fields := []string{
+		notification.GetSubject().GetTitle(),
+		notification.GetRepository().GetFullName(),
+		notification.GetReason(),
+		notification.GetSubject().GetType(),
+	}
+
+	for _, field := range fields {
+		if strings.Contains(strings.ToLower(field), viewFilter.query) {
+			return true
+		}
+	}
+
+	return false
+}
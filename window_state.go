@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver/desktop"
+)
+
+const WINDOW_WIDTH float32 = 400
+const WINDOW_HEIGHT float32 = 600
+const COMPACT_WINDOW_WIDTH float32 = 360
+const COMPACT_WINDOW_HEIGHT float32 = 480
+
+var compactWindow bool
+var lastViewRestored bool
+
+// newMainWindow creates the main window, or with compact a borderless one
+// that hides when the app loses focus, like a tray popover. Fyne can neither
+// place windows nor tell where the tray icon is, so it is left to the window
+// manager where it appears.
+func newMainWindow(compact bool) fyne.Window {
+	drv, ok := notifierApp.Driver().(desktop.Driver)
+
+	if !compact || !ok {
+		w := notifierApp.NewWindow("Github Notifications")
+		w.Resize(savedWindowSize(fyne.NewSize(WINDOW_WIDTH, WINDOW_HEIGHT)))
+
+		return w
+	}
+
+	compactWindow = true
+
+	w := drv.CreateSplashWindow()
+	w.SetTitle("Github Notifications")
+	w.Resize(fyne.NewSize(COMPACT_WINDOW_WIDTH, COMPACT_WINDOW_HEIGHT))
+
+	return w
+}
+
+func savedWindowSize(fallback fyne.Size) fyne.Size {
+	preferences := notifierApp.Preferences()
+
+	width := preferences.FloatWithFallback("window_width", float64(fallback.Width))
+	height := preferences.FloatWithFallback("window_height", float64(fallback.Height))
+
+	return fyne.NewSize(float32(width), float32(height))
+}
+
+// saveWindowState remembers the window size and what was open in it for the
+// next session. The compact window always has the same size.
+func saveWindowState() {
+	preferences := notifierApp.Preferences()
+
+	if size := window.Canvas().Size(); !compactWindow && size.Width > 0 && size.Height > 0 {
+		width := size.Width
+
+		// the list's width, as the pane widens the window again when it is
+		// restored
+		if from, to := detailWidening(); from != 0 && isWidenedWidth(width, to) {
+			width = from
+		}
+
+		preferences.SetFloat("window_width", float64(width))
+		preferences.SetFloat("window_height", float64(size.Height))
+	}
+
+	detailID := currentDetailNotificationID()
+
+	preferences.SetString("detail_notification", detailID)
+
+	if detailID != "" {
+		preferences.SetFloat("detail_offset", notificationSplit.Offset)
+	}
+}
+
+// restoreLastView reopens the notification that was shown in the detail
+// pane, once the first notifications have loaded and the window is shown,
+// so starting in the tray does not open the pane in the hidden window.
+func restoreLastView() {
+	if lastViewRestored || !isWindowVisible() || notificationStore.Len() == 0 {
+		return
+	}
+
+	lastViewRestored = true
+
+	preferences := notifierApp.Preferences()
+	index := notificationStore.IndexOf(preferences.String("detail_notification"))
+
+	if index == -1 {
+		return
+	}
+
+	// selecting the row opens the pane, and the keyboard acts on it
+	notificationListComponent.Select(index)
+	notificationSplit.SetOffset(preferences.FloatWithFallback("detail_offset", DETAIL_PANE_OFFSET))
+}
+
+// isWidenedWidth reports whether width is still the one the detail pane
+// widened the window to, give or take the rounding of the window manager.
+func isWidenedWidth(width float32, widenedTo float32) bool {
+	return width > widenedTo-1 && width < widenedTo+1
+}
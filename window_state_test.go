@@ -0,0 +1,103 @@
+package main
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"github.com/google/go-github/v55/github"
+)
+
+func TestSaveWindowStateKeepsListWidth(t *testing.T) {
+	fake := useFakeGitHub(t, 0)
+	notification := fake.notification(1)
+
+	t.Cleanup(func() {
+		runOnUI(hideNotificationDetail)
+		waitUI(t)
+		window.Resize(fyne.NewSize(WINDOW_WIDTH, WINDOW_HEIGHT))
+	})
+
+	runOnUI(hideNotificationDetail)
+	waitUI(t)
+	window.Resize(fyne.NewSize(WINDOW_WIDTH, WINDOW_HEIGHT))
+
+	runOnUI(func() {
+		showNotificationDetail(notification)
+	})
+	waitUI(t)
+
+	if width := window.Canvas().Size().Width; width <= WINDOW_WIDTH {
+		t.Fatalf("window not widened for the pane, width %v", width)
+	}
+
+	saveWindowState()
+
+	if width := notifierApp.Preferences().Float("window_width"); width != float64(WINDOW_WIDTH) {
+		t.Errorf("saved width %v, want the list's %v", width, WINDOW_WIDTH)
+	}
+
+	runOnUI(hideNotificationDetail)
+	waitUI(t)
+
+	if width := window.Canvas().Size().Width; width != WINDOW_WIDTH {
+		t.Errorf("width %v after closing the pane, want %v", width, WINDOW_WIDTH)
+	}
+
+	// resized by the user while the pane is open
+	runOnUI(func() {
+		showNotificationDetail(notification)
+		window.Resize(fyne.NewSize(1000, WINDOW_HEIGHT))
+	})
+	waitUI(t)
+
+	saveWindowState()
+
+	if width := notifierApp.Preferences().Float("window_width"); width != 1000 {
+		t.Errorf("saved width %v, want the resized 1000", width)
+	}
+}
+
+func TestRestoreLastViewWaitsForWindow(t *testing.T) {
+	fake := useFakeGitHub(t, 0)
+	visible := isWindowVisible()
+
+	t.Cleanup(func() {
+		runOnUI(func() {
+			notificationListComponent.UnselectAll()
+			hideNotificationDetail()
+		})
+		waitUI(t)
+		window.Resize(fyne.NewSize(WINDOW_WIDTH, WINDOW_HEIGHT))
+		setWindowVisible(visible)
+	})
+
+	runOnUI(hideNotificationDetail)
+	waitUI(t)
+
+	notifierApp.Preferences().SetString("detail_notification", "2")
+	lastViewRestored = false
+	setWindowVisible(false)
+
+	// started in the tray
+	runOnUI(func() {
+		addNotifications([]*github.Notification{fake.notification(1), fake.notification(2)}, nil)
+	})
+	waitUI(t)
+
+	if id := currentDetailNotificationID(); id != "" {
+		t.Fatalf("pane opened in the hidden window for %q", id)
+	}
+
+	runOnUI(showWindow)
+	waitUI(t)
+	waitUI(t)
+
+	if id := currentDetailNotificationID(); id != "2" {
+		t.Errorf("pane shows %q after showing the window, want 2", id)
+	}
+
+	// so the keyboard acts on the restored notification
+	if id, _ := notificationStore.Selected(); id != "2" {
+		t.Errorf("row %q selected, want 2", id)
+	}
+}